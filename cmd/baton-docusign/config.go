@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/conductorone/baton-sdk/pkg/field"
 	"github.com/spf13/viper"
 )
@@ -40,6 +42,68 @@ var (
 		field.WithDescription("Optional. Refresh token."),
 	)
 
+	adminApiUrlField = field.StringField(
+		"admin-api-url",
+		field.WithDescription("The base URL of the DocuSign Admin API"),
+		field.WithDefaultValue("https://api-d.docusign.net"),
+	)
+
+	organizationIdField = field.StringField(
+		"organization-id",
+		field.WithDescription("Optional. DocuSign organization ID, enables Admin API lookups such as SSO status"),
+	)
+
+	dormantDaysField = field.IntField(
+		"dormant-days",
+		field.WithDescription("Optional. Flag users without login or sending activity in this many days as dormant (0 disables)"),
+		field.WithDefaultValue(0),
+	)
+
+	envelopeUsageDaysField = field.IntField(
+		"envelope-usage-days",
+		field.WithDescription("Optional. Attach per-user envelope counts over this many days to users, costs extra API calls (0 disables)"),
+		field.WithDefaultValue(0),
+	)
+
+	internalDomainsField = field.StringSliceField(
+		"internal-domains",
+		field.WithDescription("Optional. Email domains treated as internal when flagging external users, extends the organization's verified domains"),
+	)
+
+	fallbackPermissionProfileField = field.StringField(
+		"fallback-permission-profile-id",
+		field.WithDescription("Optional. Permission profile ID users are moved to when a permission profile assignment is revoked"),
+	)
+
+	permissionCatalogFileField = field.StringField(
+		"permission-catalog-file",
+		field.WithDescription("Optional. Path to a JSON file extending or overriding the built-in permission catalog"),
+	)
+
+	permissionResourceModeField = field.StringField(
+		"permission-resource-mode",
+		field.WithDescription("Optional. How permissions are synced: 'singleton' for one docusign-permissions resource, 'per-permission' for one resource per permission"),
+		field.WithDefaultValue("singleton"),
+	)
+
+	skipEveryoneGroupGrantsField = field.BoolField(
+		"skip-everyone-group-grants",
+		field.WithDescription("Optional. Do not sync the member grants of the Everyone group, which every user holds"),
+		field.WithDefaultValue(false),
+	)
+
+	groupMembershipStrategyField = field.StringField(
+		"group-membership-strategy",
+		field.WithDescription("Optional. How group memberships are synced: 'group-centric' pages through each group's users, 'user-centric' reads each user's group list and saves API calls on accounts with many groups"),
+		field.WithDefaultValue("group-centric"),
+	)
+
+	reactivateClosedUsersField = field.BoolField(
+		"reactivate-closed-users",
+		field.WithDescription("Optional. Reactivate closed users before granting them a group or permission profile"),
+		field.WithDefaultValue(false),
+	)
+
 	ConfigurationFields = []field.SchemaField{
 		apiUrlField,
 		accountField,
@@ -47,11 +111,37 @@ var (
 		clientSecretField,
 		redirectURIField,
 		refreshTokenField,
+		adminApiUrlField,
+		organizationIdField,
+		dormantDaysField,
+		envelopeUsageDaysField,
+		internalDomainsField,
+		fallbackPermissionProfileField,
+		permissionCatalogFileField,
+		permissionResourceModeField,
+		skipEveryoneGroupGrantsField,
+		groupMembershipStrategyField,
+		reactivateClosedUsersField,
 	}
 
 	FieldRelationships = []field.SchemaFieldRelationship{}
 )
 
 func ValidateConfig(v *viper.Viper) error {
+	for _, f := range []field.SchemaField{dormantDaysField, envelopeUsageDaysField} {
+		if v.GetInt(f.FieldName) < 0 {
+			return fmt.Errorf("%s must not be negative", f.FieldName)
+		}
+	}
+	switch mode := v.GetString(permissionResourceModeField.FieldName); mode {
+	case "", "singleton", "per-permission":
+	default:
+		return fmt.Errorf("%s must be 'singleton' or 'per-permission', got %q", permissionResourceModeField.FieldName, mode)
+	}
+	switch strategy := v.GetString(groupMembershipStrategyField.FieldName); strategy {
+	case "", "group-centric", "user-centric":
+	default:
+		return fmt.Errorf("%s must be 'group-centric' or 'user-centric', got %q", groupMembershipStrategyField.FieldName, strategy)
+	}
 	return nil
 }
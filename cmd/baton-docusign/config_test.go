@@ -13,8 +13,54 @@ func TestConfigs(t *testing.T) {
 		FieldRelationships...,
 	)
 
+	requiredConfigs := map[string]string{
+		"account-id":   "account",
+		"clientId":     "client-id",
+		"clientSecret": "client-secret",
+		"redirect-uri": "https://example.com/callback",
+	}
+	withConfigs := func(extra map[string]string) map[string]string {
+		configs := make(map[string]string, len(requiredConfigs)+len(extra))
+		for key, value := range requiredConfigs {
+			configs[key] = value
+		}
+		for key, value := range extra {
+			configs[key] = value
+		}
+		return configs
+	}
+
 	testCases := []test.TestCase{
-		// Add test cases here.
+		{
+			Configs: withConfigs(nil),
+			IsValid: true,
+			Message: "required fields only",
+		},
+		{
+			Configs: withConfigs(map[string]string{"dormant-days": "90"}),
+			IsValid: true,
+			Message: "dormant user detection enabled",
+		},
+		{
+			Configs: withConfigs(map[string]string{"dormant-days": "-1"}),
+			IsValid: false,
+			Message: "negative dormant days",
+		},
+		{
+			Configs: withConfigs(map[string]string{"envelope-usage-days": "-30"}),
+			IsValid: false,
+			Message: "negative envelope usage days",
+		},
+		{
+			Configs: withConfigs(map[string]string{"group-membership-strategy": "user-centric"}),
+			IsValid: true,
+			Message: "user-centric group memberships",
+		},
+		{
+			Configs: withConfigs(map[string]string{"group-membership-strategy": "per-user"}),
+			IsValid: false,
+			Message: "unknown group membership strategy",
+		},
 	}
 
 	test.ExerciseTestCases(t, configurationSchema, ValidateConfig, testCases)
@@ -48,14 +48,27 @@ func getConnector(ctx context.Context, v *viper.Viper) (types.ConnectorServer, e
 		return nil, err
 	}
 
-	docusignApi := v.GetString(apiUrlField.FieldName)
-	docusignAccount := v.GetString(accountField.FieldName)
-	docusignClientId := v.GetString(clientIdField.FieldName)
-	docusignClientSecret := v.GetString(clientSecretField.FieldName)
-	docusignRedirectURI := v.GetString(redirectURIField.FieldName)
-	docusignRefreshToken := v.GetString(refreshTokenField.FieldName)
+	cfg := connectorSchema.Config{
+		ApiUrl:                      v.GetString(apiUrlField.FieldName),
+		AccountId:                   v.GetString(accountField.FieldName),
+		ClientId:                    v.GetString(clientIdField.FieldName),
+		ClientSecret:                v.GetString(clientSecretField.FieldName),
+		RedirectURI:                 v.GetString(redirectURIField.FieldName),
+		RefreshToken:                v.GetString(refreshTokenField.FieldName),
+		AdminApiUrl:                 v.GetString(adminApiUrlField.FieldName),
+		OrganizationId:              v.GetString(organizationIdField.FieldName),
+		DormantDays:                 v.GetInt(dormantDaysField.FieldName),
+		EnvelopeUsageDays:           v.GetInt(envelopeUsageDaysField.FieldName),
+		InternalDomains:             v.GetStringSlice(internalDomainsField.FieldName),
+		FallbackPermissionProfileId: v.GetString(fallbackPermissionProfileField.FieldName),
+		PermissionCatalogFile:       v.GetString(permissionCatalogFileField.FieldName),
+		PermissionResourceMode:      v.GetString(permissionResourceModeField.FieldName),
+		SkipEveryoneGroupGrants:     v.GetBool(skipEveryoneGroupGrantsField.FieldName),
+		GroupMembershipStrategy:     v.GetString(groupMembershipStrategyField.FieldName),
+		ReactivateClosedUsers:       v.GetBool(reactivateClosedUsersField.FieldName),
+	}
 
-	cb, err := connectorSchema.New(ctx, docusignApi, docusignAccount, docusignClientId, docusignClientSecret, docusignRedirectURI, docusignRefreshToken)
+	cb, err := connectorSchema.New(ctx, cfg)
 	if err != nil {
 		l.Error("error creating connector", zap.Error(err))
 		return nil, err
@@ -5,27 +5,60 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/uhttp"
 	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // API endpoint constants.
 const (
+	getAccount     = "/restapi/v2.1/accounts/%s"
 	getUsers       = "/restapi/v2.1/accounts/%s/users"
 	getGroups      = "/restapi/v2.1/accounts/%s/groups"
 	getPermissions = "/restapi/v2.1/accounts/%s/users/%s"
+	updateUser     = "/restapi/v2.1/accounts/%s/users/%s"
+	userSettings   = "/restapi/v2.1/accounts/%s/users/%s/settings"
 	getGroupUsers  = "/restapi/v2.1/accounts/%s/groups/%s/users"
 	createUsers    = "/restapi/v2.1/accounts/%s/users"
+	closeUsers     = "/restapi/v2.1/accounts/%s/users"
+	getEnvelopes   = "/restapi/v2.1/accounts/%s/envelopes"
+
+	createGroups     = "/restapi/v2.1/accounts/%s/groups"
+	deleteGroups     = "/restapi/v2.1/accounts/%s/groups"
+	addGroupUsers    = "/restapi/v2.1/accounts/%s/groups/%s/users"
+	removeGroupUsers = "/restapi/v2.1/accounts/%s/groups/%s/users"
+	groupBrands      = "/restapi/v2.1/accounts/%s/groups/%s/brands"
+
+	getBrands = "/restapi/v2.1/accounts/%s/brands"
+
+	getSigningGroups     = "/restapi/v2.1/accounts/%s/signing_groups"
+	getSigningGroupUsers = "/restapi/v2.1/accounts/%s/signing_groups/%s/users"
+
+	getPermissionProfiles   = "/restapi/v2.1/accounts/%s/permission_profiles"
+	createPermissionProfile = "/restapi/v2.1/accounts/%s/permission_profiles"
+	deletePermissionProfile = "/restapi/v2.1/accounts/%s/permission_profiles/%s"
+
+	getUserCloudStorage    = "/restapi/v2.1/accounts/%s/users/%s/cloud_storage"
+	deleteUserCloudStorage = "/restapi/v2.1/accounts/%s/users/%s/cloud_storage/%s"
+
+	getOrganizationUserProfile = "/management/v2.2/organizations/%s/users/profile"
+	getIdentityProviders       = "/management/v2/organizations/%s/identity_providers"
+	getReservedDomains         = "/management/v2/organizations/%s/reserved_domains"
 )
 
 // Client wraps HTTP interactions with the DocuSign API, handling auth and base URL.
 type Client struct {
-	apiUrl      string
-	tokenSource oauth2.TokenSource
-	accountId   string
-	wrapper     *uhttp.BaseHttpClient
+	apiUrl         string
+	tokenSource    oauth2.TokenSource
+	accountId      string
+	wrapper        *uhttp.BaseHttpClient
+	adminApiUrl    string
+	organizationId string
 }
 
 // New constructs a Client, choosing OAuth2 interactive flow or direct token based on accessToken.
@@ -59,7 +92,20 @@ func NewClient(ctx context.Context, apiUrl, accountId string, tokenSource oauth2
 	}
 }
 
+// WithOrganization configures the DocuSign Admin API base URL and organization used for organization-level calls.
+func (c *Client) WithOrganization(adminApiUrl, organizationId string) *Client {
+	c.adminApiUrl = adminApiUrl
+	c.organizationId = organizationId
+	return c
+}
+
+// HasOrganization reports whether an organization is configured for Admin API calls.
+func (c *Client) HasOrganization() bool {
+	return c.adminApiUrl != "" && c.organizationId != ""
+}
+
 // GetUsers fetches a page of users and returns users, next page token, and annotations.
+// DocuSign only returns user settings with additional_info=true, which is requested when options.AdditionalInfo is set.
 func (c *Client) GetUsers(ctx context.Context, options PageOptions) ([]User, string, annotations.Annotations, error) {
 	var usersResponse UsersResponse
 
@@ -72,6 +118,11 @@ func (c *Client) GetUsers(ctx context.Context, options PageOptions) ([]User, str
 	if err != nil {
 		return nil, "", nil, err
 	}
+	if options.AdditionalInfo {
+		q := usersURL.Query()
+		q.Set("additional_info", "true")
+		usersURL.RawQuery = q.Encode()
+	}
 
 	_, annos, err := c.doRequest(ctx, http.MethodGet, usersURL, &usersResponse)
 	if err != nil {
@@ -82,6 +133,41 @@ func (c *Client) GetUsers(ctx context.Context, options PageOptions) ([]User, str
 	return usersResponse.Users, nextToken, annos, nil
 }
 
+// GetAccount fetches the name and identifiers of the account.
+func (c *Client) GetAccount(ctx context.Context) (*AccountInformation, annotations.Annotations, error) {
+	accountURL, err := buildURL(c.apiUrl, getAccount, c.accountId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var account AccountInformation
+	_, annos, err := c.doRequest(ctx, http.MethodGet, accountURL, &account)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching account: %w", err)
+	}
+
+	return &account, annos, nil
+}
+
+// GetUsersByEmail fetches the account users with the given email address.
+func (c *Client) GetUsersByEmail(ctx context.Context, email string) ([]User, annotations.Annotations, error) {
+	usersURL, err := buildURL(c.apiUrl, getUsers, c.accountId)
+	if err != nil {
+		return nil, nil, err
+	}
+	q := usersURL.Query()
+	q.Set("email", email)
+	usersURL.RawQuery = q.Encode()
+
+	var usersResponse UsersResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, usersURL, &usersResponse)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching users by email: %w", err)
+	}
+
+	return usersResponse.Users, annos, nil
+}
+
 // GetGroups fetches a page of groups and handles pagination and rate limit annotations.
 func (c *Client) GetGroups(ctx context.Context, options PageOptions) ([]Group, string, annotations.Annotations, error) {
 	var groupsResponse GroupsResponse
@@ -128,6 +214,254 @@ func (c *Client) GetGroupUsers(ctx context.Context, groupId string, options Page
 	return usersResponse.Users, nextToken, annos, nil
 }
 
+// CreateGroup creates a group and returns it with its new ID.
+func (c *Client) CreateGroup(ctx context.Context, group GroupCreate) (*Group, annotations.Annotations, error) {
+	groupsURL, err := buildURL(c.apiUrl, createGroups, c.accountId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response GroupsResponse
+	_, annos, err := c.doRequestWithBody(ctx, http.MethodPost, groupsURL.String(), CreateGroupsRequest{Groups: []GroupCreate{group}}, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error creating group %s: %w", group.GroupName, err)
+	}
+	if len(response.Groups) == 0 {
+		return nil, annos, fmt.Errorf("error creating group %s: empty response", group.GroupName)
+	}
+
+	created := response.Groups[0]
+	if created.ErrorDetails != nil {
+		return nil, annos, fmt.Errorf("error creating group %s: %s: %s", group.GroupName, created.ErrorDetails.ErrorCode, created.ErrorDetails.Message)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return nil, annos, fmt.Errorf("error clearing cache after creating group %s: %w", group.GroupName, err)
+	}
+
+	return &created, annos, nil
+}
+
+// DeleteGroup deletes a group.
+func (c *Client) DeleteGroup(ctx context.Context, groupID string) (annotations.Annotations, error) {
+	groupsURL, err := buildURL(c.apiUrl, deleteGroups, c.accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	var response GroupsResponse
+	_, annos, err := c.doRequestWithBody(ctx, http.MethodDelete, groupsURL.String(), DeleteGroupsRequest{Groups: []GroupReference{{GroupId: groupID}}}, &response)
+	if err != nil {
+		return annos, fmt.Errorf("error deleting group %s: %w", groupID, err)
+	}
+	for _, group := range response.Groups {
+		if group.ErrorDetails != nil {
+			return annos, fmt.Errorf("error deleting group %s: %s: %s", groupID, group.ErrorDetails.ErrorCode, group.ErrorDetails.Message)
+		}
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return annos, fmt.Errorf("error clearing cache after deleting group %s: %w", groupID, err)
+	}
+
+	return annos, nil
+}
+
+// GetBrands fetches the brands of the account, along with the account's default brands.
+func (c *Client) GetBrands(ctx context.Context) (*BrandsResponse, annotations.Annotations, error) {
+	brandsURL, err := buildURL(c.apiUrl, getBrands, c.accountId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response BrandsResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, brandsURL, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching brands: %w", err)
+	}
+
+	return &response, annos, nil
+}
+
+// GetSigningGroups fetches the signing groups of the account.
+func (c *Client) GetSigningGroups(ctx context.Context) ([]SigningGroup, annotations.Annotations, error) {
+	groupsURL, err := buildURL(c.apiUrl, getSigningGroups, c.accountId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response SigningGroupsResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, groupsURL, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching signing groups: %w", err)
+	}
+
+	return response.Groups, annos, nil
+}
+
+// GetSigningGroupUsers fetches the members of a signing group.
+func (c *Client) GetSigningGroupUsers(ctx context.Context, signingGroupID string) ([]SigningGroupUser, annotations.Annotations, error) {
+	usersURL, err := buildURL(c.apiUrl, getSigningGroupUsers, c.accountId, signingGroupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response SigningGroupUsersResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, usersURL, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching users of signing group %s: %w", signingGroupID, err)
+	}
+
+	return response.Users, annos, nil
+}
+
+// AddSigningGroupUsers adds members to a signing group. Members are identified by their email address.
+func (c *Client) AddSigningGroupUsers(ctx context.Context, signingGroupID string, users []SigningGroupUser) (*SigningGroupUsersResponse, annotations.Annotations, error) {
+	return c.updateSigningGroupUsers(ctx, http.MethodPut, signingGroupID, users)
+}
+
+// RemoveSigningGroupUsers removes members from a signing group. Members are identified by their email address.
+func (c *Client) RemoveSigningGroupUsers(ctx context.Context, signingGroupID string, users []SigningGroupUser) (*SigningGroupUsersResponse, annotations.Annotations, error) {
+	return c.updateSigningGroupUsers(ctx, http.MethodDelete, signingGroupID, users)
+}
+
+// updateSigningGroupUsers sends the members to the signing group users endpoint with the given method.
+// DocuSign reports per-member failures in the response rather than failing the request.
+func (c *Client) updateSigningGroupUsers(ctx context.Context, method, signingGroupID string, users []SigningGroupUser) (*SigningGroupUsersResponse, annotations.Annotations, error) {
+	if len(users) == 0 {
+		return nil, nil, fmt.Errorf("at least one user must be provided")
+	}
+
+	usersURL, err := buildURL(c.apiUrl, getSigningGroupUsers, c.accountId, signingGroupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request := SigningGroupUsersRequest{Users: users}
+	var response SigningGroupUsersResponse
+	_, annos, err := c.doRequestWithBody(ctx, method, usersURL.String(), request, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error updating users of signing group %s: %w", signingGroupID, err)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return nil, annos, fmt.Errorf("error clearing cache after updating signing group %s: %w", signingGroupID, err)
+	}
+
+	return &response, annos, nil
+}
+
+// GetGroupBrands fetches the brands the group can send with.
+func (c *Client) GetGroupBrands(ctx context.Context, groupID string) ([]Brand, annotations.Annotations, error) {
+	brandsURL, err := buildURL(c.apiUrl, groupBrands, c.accountId, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response GroupBrandsResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, brandsURL, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching brands of group %s: %w", groupID, err)
+	}
+
+	return response.BrandOptions, annos, nil
+}
+
+// AddGroupBrands lets the group send with the given brands.
+func (c *Client) AddGroupBrands(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error) {
+	if len(brandIDs) == 0 {
+		return nil, fmt.Errorf("at least one brand must be provided")
+	}
+
+	brandsURL, err := buildURL(c.apiUrl, groupBrands, c.accountId, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	request := GroupBrandsRequest{}
+	for _, brandID := range brandIDs {
+		request.Brands = append(request.Brands, BrandReference{BrandId: brandID})
+	}
+
+	_, annos, err := c.doRequestWithBody(ctx, http.MethodPut, brandsURL.String(), request, nil)
+	if err != nil {
+		return annos, fmt.Errorf("error adding brands to group %s: %w", groupID, err)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return annos, fmt.Errorf("error clearing cache after updating group %s: %w", groupID, err)
+	}
+
+	return annos, nil
+}
+
+// RemoveGroupBrands stops the group from sending with the given brands.
+func (c *Client) RemoveGroupBrands(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error) {
+	if len(brandIDs) == 0 {
+		return nil, fmt.Errorf("at least one brand must be provided")
+	}
+
+	brandsURL, err := buildURL(c.apiUrl, groupBrands, c.accountId, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	request := GroupBrandsRequest{}
+	for _, brandID := range brandIDs {
+		request.Brands = append(request.Brands, BrandReference{BrandId: brandID})
+	}
+
+	_, annos, err := c.doRequestWithBody(ctx, http.MethodDelete, brandsURL.String(), request, nil)
+	if err != nil {
+		return annos, fmt.Errorf("error removing brands from group %s: %w", groupID, err)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return annos, fmt.Errorf("error clearing cache after updating group %s: %w", groupID, err)
+	}
+
+	return annos, nil
+}
+
+// AddGroupUsers adds users to a group. Users that could not be added are reported with error details in the response.
+func (c *Client) AddGroupUsers(ctx context.Context, groupID string, userIDs []string) (*GroupUsersResponse, annotations.Annotations, error) {
+	return c.updateGroupUsers(ctx, http.MethodPut, addGroupUsers, groupID, userIDs)
+}
+
+// RemoveGroupUsers removes users from a group. Users that could not be removed are reported with error details in the response.
+func (c *Client) RemoveGroupUsers(ctx context.Context, groupID string, userIDs []string) (*GroupUsersResponse, annotations.Annotations, error) {
+	return c.updateGroupUsers(ctx, http.MethodDelete, removeGroupUsers, groupID, userIDs)
+}
+
+// updateGroupUsers sends a group membership change, then clears the HTTP cache so that group users are read fresh.
+func (c *Client) updateGroupUsers(ctx context.Context, method, endpoint, groupID string, userIDs []string) (*GroupUsersResponse, annotations.Annotations, error) {
+	if len(userIDs) == 0 {
+		return nil, nil, fmt.Errorf("at least one user must be provided")
+	}
+
+	groupUsersURL, err := buildURL(c.apiUrl, endpoint, c.accountId, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request := GroupUsersRequest{}
+	for _, userID := range userIDs {
+		request.Users = append(request.Users, UserReference{UserId: userID})
+	}
+
+	var response GroupUsersResponse
+	_, annos, err := c.doRequestWithBody(ctx, method, groupUsersURL.String(), request, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error updating users of group %s: %w", groupID, err)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return nil, annos, fmt.Errorf("error clearing cache after updating group %s: %w", groupID, err)
+	}
+
+	return &response, annos, nil
+}
+
 // GetUserDetails fetches detailed information for a specific user, including permissions.
 func (c *Client) GetUserDetails(ctx context.Context, userID string) (*UserDetail, annotations.Annotations, error) {
 	userURL, err := buildURL(c.apiUrl, getPermissions, c.accountId, userID)
@@ -144,6 +478,62 @@ func (c *Client) GetUserDetails(ctx context.Context, userID string) (*UserDetail
 	return &userDetail, annos, nil
 }
 
+// UpdateUser applies the non-empty fields of the update to a user.
+// Setting UserStatus to "Active" reactivates a closed user.
+func (c *Client) UpdateUser(ctx context.Context, userID string, update UserUpdate) (annotations.Annotations, error) {
+	userURL, err := buildURL(c.apiUrl, updateUser, c.accountId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, annos, err := c.doRequestWithBody(ctx, http.MethodPut, userURL.String(), update, nil)
+	if err != nil {
+		return annos, fmt.Errorf("error updating user %s: %w", userID, err)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return annos, fmt.Errorf("error clearing cache after updating user %s: %w", userID, err)
+	}
+
+	return annos, nil
+}
+
+// GetUserSettings fetches the settings of a user, including the ones UserSettings does not declare.
+func (c *Client) GetUserSettings(ctx context.Context, userID string) (map[string]interface{}, annotations.Annotations, error) {
+	settingsURL, err := buildURL(c.apiUrl, userSettings, c.accountId, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var settings map[string]interface{}
+	_, annos, err := c.doRequest(ctx, http.MethodGet, settingsURL, &settings)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching settings for user %s: %w", userID, err)
+	}
+
+	return settings, annos, nil
+}
+
+// UpdateUserSettings changes the given settings of a user. Nested settings are passed as nested maps.
+// The HTTP cache is cleared afterwards so that the next read returns the updated settings.
+func (c *Client) UpdateUserSettings(ctx context.Context, userID string, settings map[string]interface{}) (annotations.Annotations, error) {
+	settingsURL, err := buildURL(c.apiUrl, userSettings, c.accountId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, annos, err := c.doRequestWithBody(ctx, http.MethodPut, settingsURL.String(), settings, nil)
+	if err != nil {
+		return annos, fmt.Errorf("error updating settings for user %s: %w", userID, err)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return annos, fmt.Errorf("error clearing cache after updating user %s: %w", userID, err)
+	}
+
+	return annos, nil
+}
+
 // CreateUsers sends a bulk create request for new users in the account.
 func (c *Client) CreateUsers(ctx context.Context, request CreateUsersRequest) (*UserCreationResponse, annotations.Annotations, error) {
 	if len(request.NewUsers) == 0 {
@@ -164,6 +554,292 @@ func (c *Client) CreateUsers(ctx context.Context, request CreateUsersRequest) (*
 	return &response, annon, nil
 }
 
+// GetLastSentEnvelopeDate returns the sent date of the most recent envelope sent by the user since the given time.
+// It returns nil when the user has not sent any envelope in that period.
+func (c *Client) GetLastSentEnvelopeDate(ctx context.Context, userID string, since time.Time) (*time.Time, annotations.Annotations, error) {
+	envelopesURL, err := buildURL(c.apiUrl, getEnvelopes, c.accountId)
+	if err != nil {
+		return nil, nil, err
+	}
+	q := envelopesURL.Query()
+	q.Set("from_date", since.UTC().Format(time.RFC3339))
+	q.Set("user_id", userID)
+	q.Set("order_by", "sent")
+	q.Set("order", "desc")
+	q.Set("count", "1")
+	envelopesURL.RawQuery = q.Encode()
+
+	var response EnvelopesResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, envelopesURL, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching envelopes for user %s: %w", userID, err)
+	}
+
+	var lastSent *time.Time
+	for _, envelope := range response.Envelopes {
+		sent, err := ParseTime(envelope.SentDateTime)
+		if err != nil || sent == nil {
+			continue
+		}
+		if lastSent == nil || sent.After(*lastSent) {
+			lastSent = sent
+		}
+	}
+
+	return lastSent, annos, nil
+}
+
+// GetEnvelopes fetches a page of envelopes sent by the user whose status changed since the given time.
+func (c *Client) GetEnvelopes(ctx context.Context, userID string, since time.Time, options PageOptions) ([]Envelope, string, annotations.Annotations, error) {
+	var envelopesResponse EnvelopesResponse
+
+	baseURL, err := url.Parse(c.apiUrl)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	envelopesURL, err := preparePagedRequest(baseURL, fmt.Sprintf(getEnvelopes, c.accountId), options)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	q := envelopesURL.Query()
+	q.Set("from_date", since.UTC().Format(time.RFC3339))
+	q.Set("user_id", userID)
+	envelopesURL.RawQuery = q.Encode()
+
+	_, annos, err := c.doRequest(ctx, http.MethodGet, envelopesURL, &envelopesResponse)
+	if err != nil {
+		return nil, "", annos, fmt.Errorf("error fetching envelopes for user %s: %w", userID, err)
+	}
+
+	nextToken := getNextToken(envelopesResponse.Page)
+	return envelopesResponse.Envelopes, nextToken, annos, nil
+}
+
+// CloseUsers closes the given users in the account. Closed users can be reactivated later.
+func (c *Client) CloseUsers(ctx context.Context, userIDs []string) (*UsersCloseResponse, annotations.Annotations, error) {
+	if len(userIDs) == 0 {
+		return nil, nil, fmt.Errorf("at least one user must be provided")
+	}
+
+	closeUsersURL, err := buildURL(c.apiUrl, closeUsers, c.accountId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request := UsersCloseRequest{}
+	for _, userID := range userIDs {
+		request.Users = append(request.Users, UserReference{UserId: userID})
+	}
+
+	var response UsersCloseResponse
+	_, annos, err := c.doRequestWithBody(ctx, http.MethodDelete, closeUsersURL.String(), request, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error closing users: %w", err)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return nil, annos, fmt.Errorf("error clearing cache after closing users: %w", err)
+	}
+
+	return &response, annos, nil
+}
+
+// GetPermissionProfiles fetches all permission profiles of the account, including their settings.
+func (c *Client) GetPermissionProfiles(ctx context.Context) ([]PermissionProfile, annotations.Annotations, error) {
+	profilesURL, err := buildURL(c.apiUrl, getPermissionProfiles, c.accountId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response PermissionProfilesResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, profilesURL, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching permission profiles: %w", err)
+	}
+
+	return response.PermissionProfiles, annos, nil
+}
+
+// CreatePermissionProfile creates a permission profile with the given name and settings.
+func (c *Client) CreatePermissionProfile(ctx context.Context, request PermissionProfileCreateRequest) (*PermissionProfile, annotations.Annotations, error) {
+	profilesURL, err := buildURL(c.apiUrl, createPermissionProfile, c.accountId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response PermissionProfile
+	_, annos, err := c.doRequestWithBody(ctx, http.MethodPost, profilesURL.String(), request, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error creating permission profile %s: %w", request.PermissionProfileName, err)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return nil, annos, fmt.Errorf("error clearing cache after creating permission profile %s: %w", request.PermissionProfileName, err)
+	}
+
+	return &response, annos, nil
+}
+
+// DeletePermissionProfile deletes a permission profile. DocuSign rejects the request while users are assigned to it.
+func (c *Client) DeletePermissionProfile(ctx context.Context, profileID string) (annotations.Annotations, error) {
+	profileURL, err := buildURL(c.apiUrl, deletePermissionProfile, c.accountId, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, annos, err := c.doRequest(ctx, http.MethodDelete, profileURL, nil)
+	if err != nil {
+		return annos, fmt.Errorf("error deleting permission profile %s: %w", profileID, err)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return annos, fmt.Errorf("error clearing cache after deleting permission profile %s: %w", profileID, err)
+	}
+
+	return annos, nil
+}
+
+// HasPermissionProfileUsers reports whether any user of the account is assigned the permission profile.
+// The users list can't be filtered by profile, so the user count of each profile is requested instead.
+func (c *Client) HasPermissionProfileUsers(ctx context.Context, profileID string) (bool, annotations.Annotations, error) {
+	profilesURL, err := buildURL(c.apiUrl, getPermissionProfiles, c.accountId)
+	if err != nil {
+		return false, nil, err
+	}
+	q := profilesURL.Query()
+	q.Set("include", "user_count")
+	profilesURL.RawQuery = q.Encode()
+
+	var response PermissionProfilesResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, profilesURL, &response)
+	if err != nil {
+		return false, annos, fmt.Errorf("error fetching users of permission profile %s: %w", profileID, err)
+	}
+
+	for _, profile := range response.PermissionProfiles {
+		if profile.PermissionProfileId != profileID {
+			continue
+		}
+		if profile.UserCount == "" {
+			return false, annos, nil
+		}
+		userCount, err := strconv.Atoi(profile.UserCount)
+		if err != nil {
+			return false, annos, fmt.Errorf("invalid user count %q for permission profile %s: %w", profile.UserCount, profileID, err)
+		}
+		return userCount > 0, annos, nil
+	}
+
+	return false, annos, nil
+}
+
+// GetUserCloudStorage fetches the cloud storage providers configured for a user.
+func (c *Client) GetUserCloudStorage(ctx context.Context, userID string) ([]CloudStorageProvider, annotations.Annotations, error) {
+	cloudStorageURL, err := buildURL(c.apiUrl, getUserCloudStorage, c.accountId, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response CloudStorageProvidersResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, cloudStorageURL, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching cloud storage for user %s: %w", userID, err)
+	}
+
+	return response.StorageProviders, annos, nil
+}
+
+// DeleteUserCloudStorage removes the user's connection to a cloud storage provider.
+func (c *Client) DeleteUserCloudStorage(ctx context.Context, userID, serviceID string) (annotations.Annotations, error) {
+	cloudStorageURL, err := buildURL(c.apiUrl, deleteUserCloudStorage, c.accountId, userID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, annos, err := c.doRequest(ctx, http.MethodDelete, cloudStorageURL, nil)
+	if err != nil {
+		return annos, fmt.Errorf("error deleting cloud storage %s for user %s: %w", serviceID, userID, err)
+	}
+
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		return annos, fmt.Errorf("error clearing cache after deleting cloud storage %s for user %s: %w", serviceID, userID, err)
+	}
+
+	return annos, nil
+}
+
+// GetOrganizationUserProfile fetches the Admin API profile, including linked identities, of the organization user with the given email.
+// It returns nil when the email does not belong to a user of the organization.
+func (c *Client) GetOrganizationUserProfile(ctx context.Context, email string) (*OrganizationUser, annotations.Annotations, error) {
+	if !c.HasOrganization() {
+		return nil, nil, fmt.Errorf("organization ID is not configured")
+	}
+
+	profileURL, err := buildURL(c.adminApiUrl, getOrganizationUserProfile, c.organizationId)
+	if err != nil {
+		return nil, nil, err
+	}
+	q := profileURL.Query()
+	q.Set("email", email)
+	profileURL.RawQuery = q.Encode()
+
+	var response OrganizationUsersResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, profileURL, &response)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, annos, nil
+		}
+		return nil, annos, fmt.Errorf("error fetching organization user profile: %w", err)
+	}
+
+	if len(response.Users) == 0 {
+		return nil, annos, nil
+	}
+
+	return &response.Users[0], annos, nil
+}
+
+// GetIdentityProviders fetches the identity providers configured for the organization.
+func (c *Client) GetIdentityProviders(ctx context.Context) ([]IdentityProvider, annotations.Annotations, error) {
+	if !c.HasOrganization() {
+		return nil, nil, fmt.Errorf("organization ID is not configured")
+	}
+
+	providersURL, err := buildURL(c.adminApiUrl, getIdentityProviders, c.organizationId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response IdentityProvidersResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, providersURL, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching identity providers: %w", err)
+	}
+
+	return response.IdentityProviders, annos, nil
+}
+
+// GetReservedDomains fetches the domains reserved or claimed by the organization.
+func (c *Client) GetReservedDomains(ctx context.Context) ([]ReservedDomain, annotations.Annotations, error) {
+	if !c.HasOrganization() {
+		return nil, nil, fmt.Errorf("organization ID is not configured")
+	}
+
+	domainsURL, err := buildURL(c.adminApiUrl, getReservedDomains, c.organizationId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response ReservedDomainsResponse
+	_, annos, err := c.doRequest(ctx, http.MethodGet, domainsURL, &response)
+	if err != nil {
+		return nil, annos, fmt.Errorf("error fetching reserved domains: %w", err)
+	}
+
+	return response.ReservedDomains, annos, nil
+}
+
 // doRequestWithBody builds and executes a JSON POST/PUT request and decodes the response.
 func (c *Client) doRequestWithBody(
 	ctx context.Context,
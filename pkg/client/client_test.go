@@ -2,9 +2,12 @@ package client_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/conductorone/baton-docusign/pkg/client"
 	"github.com/conductorone/baton-docusign/test"
@@ -18,7 +21,23 @@ const (
 	getUserDetailsTest = "/restapi/v2.1/accounts/account123/users/u1"
 	getGroupsTest      = "/restapi/v2.1/accounts/account123/groups"
 	getGroupUsersTest  = "/restapi/v2.1/accounts/account123/groups/g1/users"
+	getGroupBrandsTest = "/restapi/v2.1/accounts/account123/groups/g1/brands"
 	getUsersTest       = "/restapi/v2.1/accounts/account123/users"
+	getEnvelopesTest   = "/restapi/v2.1/accounts/account123/envelopes"
+	getBrandsTest      = "/restapi/v2.1/accounts/account123/brands"
+
+	getSigningGroupsTest     = "/restapi/v2.1/accounts/account123/signing_groups"
+	getSigningGroupUsersTest = "/restapi/v2.1/accounts/account123/signing_groups/sg1/users"
+
+	getPermissionProfilesTest   = "/restapi/v2.1/accounts/account123/permission_profiles"
+	deletePermissionProfileTest = "/restapi/v2.1/accounts/account123/permission_profiles/2001"
+	getUserCloudStorageTest     = "/restapi/v2.1/accounts/account123/users/u1/cloud_storage"
+	deleteUserCloudStorageTest  = "/restapi/v2.1/accounts/account123/users/u1/cloud_storage/136"
+	userSettingsTest            = "/restapi/v2.1/accounts/account123/users/u1/settings"
+
+	mockOrganizationID             = "org123"
+	getOrganizationUserProfileTest = "/management/v2.2/organizations/org123/users/profile"
+	getIdentityProvidersTest       = "/management/v2/organizations/org123/identity_providers"
 )
 
 // Helper function to read mock responses from a file.
@@ -61,7 +80,119 @@ func TestClient_GetUsers(t *testing.T) {
 		assert.Len(t, users, 2)
 		assert.Equal(t, "1", users[0].UserId)
 		assert.Equal(t, "testuser2", users[1].UserName)
+		assert.Equal(t, "true", users[0].UserSettings["canSendEnvelope"])
 	})
+
+	t.Run("requests the user settings only when asked", func(t *testing.T) {
+		var additionalInfo []string
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, getUsersTest, r.URL.Path)
+			additionalInfo = append(additionalInfo, r.URL.Query().Get("additional_info"))
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(readMockResponse("users_list.json")))
+		}))
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		_, _, _, err := c.GetUsers(context.Background(), client.PageOptions{})
+		require.NoError(t, err)
+		_, _, _, err = c.GetUsers(context.Background(), client.PageOptions{AdditionalInfo: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"", "true"}, additionalInfo)
+	})
+}
+
+// newPagingServer serves a listing of total items in the pages requested with start_position and count,
+// recording the start position of every request.
+func newPagingServer(t *testing.T, total int, page func(start, end int, p client.Page) interface{}) (*httptest.Server, *[]int) {
+	var starts []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, err := strconv.Atoi(r.URL.Query().Get("start_position"))
+		require.NoError(t, err)
+		count, err := strconv.Atoi(r.URL.Query().Get("count"))
+		require.NoError(t, err)
+		starts = append(starts, start)
+
+		end := min(start+count, total)
+		p := client.Page{ResultSetSize: max(end-start, 0), TotalSetSize: total, StartPosition: start, EndPosition: max(end-1, 0)}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(page(start, end, p)))
+	}))
+	return server, &starts
+}
+
+// Test case to verify users and groups are paged until the last item, without requesting an empty page.
+func TestClient_Paging(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int
+		wantStarts []int
+	}{
+		{name: "empty listing", total: 0, wantStarts: []int{0}},
+		{name: "single partial page", total: 1, wantStarts: []int{0}},
+		{name: "exactly one full page", total: 2, wantStarts: []int{0}},
+		{name: "several pages", total: 5, wantStarts: []int{0, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run("users: "+tt.name, func(t *testing.T) {
+			server, starts := newPagingServer(t, tt.total, func(start, end int, p client.Page) interface{} {
+				response := client.UsersResponse{Users: []client.User{}, Page: p}
+				for i := start; i < end; i++ {
+					response.Users = append(response.Users, client.User{UserId: strconv.Itoa(i)})
+				}
+				return response
+			})
+			defer server.Close()
+
+			c := createClient(server.URL)
+			var users []client.User
+			pageToken := ""
+			for {
+				page, nextToken, _, err := c.GetUsers(context.Background(), client.PageOptions{PageSize: 2, PageToken: pageToken})
+				require.NoError(t, err)
+				users = append(users, page...)
+				if nextToken == "" {
+					break
+				}
+				pageToken = nextToken
+			}
+
+			assert.Len(t, users, tt.total)
+			assert.Equal(t, tt.wantStarts, *starts)
+		})
+
+		t.Run("groups: "+tt.name, func(t *testing.T) {
+			server, starts := newPagingServer(t, tt.total, func(start, end int, p client.Page) interface{} {
+				response := client.GroupsResponse{Groups: []client.Group{}, Page: p}
+				for i := start; i < end; i++ {
+					response.Groups = append(response.Groups, client.Group{GroupId: strconv.Itoa(i)})
+				}
+				return response
+			})
+			defer server.Close()
+
+			c := createClient(server.URL)
+			var groups []client.Group
+			pageToken := ""
+			for {
+				page, nextToken, _, err := c.GetGroups(context.Background(), client.PageOptions{PageSize: 2, PageToken: pageToken})
+				require.NoError(t, err)
+				groups = append(groups, page...)
+				if nextToken == "" {
+					break
+				}
+				pageToken = nextToken
+			}
+
+			assert.Len(t, groups, tt.total)
+			assert.Equal(t, tt.wantStarts, *starts)
+		})
+	}
 }
 
 // Test case to verify successful retrieval of user details.
@@ -78,6 +209,8 @@ func TestClient_GetUserDetails(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, test.MockUserID, userDetails.UserID)
 		assert.Equal(t, "Alice", userDetails.UserName)
+		assert.Equal(t, "true", userDetails.UserSettings.CanSendEnvelope)
+		assert.Equal(t, "true", userDetails.RawUserSettings["canUseNewFeature"])
 	})
 }
 
@@ -95,6 +228,7 @@ func TestClient_GetGroups(t *testing.T) {
 		require.NoError(t, err)
 		assert.Len(t, groups, 2)
 		assert.Equal(t, "Admins", groups[0].GroupName)
+		assert.Equal(t, "1002", groups[1].PermissionProfileId)
 	})
 }
 
@@ -136,3 +270,541 @@ func TestClient_CreateUsers(t *testing.T) {
 		assert.Equal(t, "new-user-1", resp.NewUsers[0].UserId)
 	})
 }
+
+// Test case to verify retrieval of an organization user profile from the Admin API.
+func TestClient_GetOrganizationUserProfile(t *testing.T) {
+	t.Run("successfully retrieves organization user profile", func(t *testing.T) {
+		mockResponse := readMockResponse("organization_user_profile.json")
+		testServer := createTestServer(t, mockResponse, getOrganizationUserProfileTest, http.MethodGet)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL).WithOrganization(testServer.URL, mockOrganizationID)
+		orgUser, _, err := c.GetOrganizationUserProfile(context.Background(), "alice@example.com")
+
+		require.NoError(t, err)
+		require.NotNil(t, orgUser)
+		assert.Equal(t, "org-user-1", orgUser.ID)
+		require.Len(t, orgUser.Identities, 1)
+		assert.Equal(t, "idp-1", orgUser.Identities[0].ProviderID)
+	})
+
+	t.Run("returns nil when the user is not in the organization", func(t *testing.T) {
+		testServer := createTestServer(t, `{"users": []}`, getOrganizationUserProfileTest, http.MethodGet)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL).WithOrganization(testServer.URL, mockOrganizationID)
+		orgUser, _, err := c.GetOrganizationUserProfile(context.Background(), "outsider@example.com")
+
+		require.NoError(t, err)
+		assert.Nil(t, orgUser)
+	})
+
+	t.Run("fails when no organization is configured", func(t *testing.T) {
+		c := createClient(test.MockBaseURL)
+		_, _, err := c.GetOrganizationUserProfile(context.Background(), "alice@example.com")
+
+		require.Error(t, err)
+	})
+}
+
+// Test case to verify retrieval of the organization's identity providers.
+func TestClient_GetIdentityProviders(t *testing.T) {
+	t.Run("successfully retrieves identity providers", func(t *testing.T) {
+		mockResponse := readMockResponse("identity_providers.json")
+		testServer := createTestServer(t, mockResponse, getIdentityProvidersTest, http.MethodGet)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL).WithOrganization(testServer.URL, mockOrganizationID)
+		providers, _, err := c.GetIdentityProviders(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, providers, 1)
+		assert.Equal(t, "Okta", providers[0].FriendlyName)
+	})
+}
+
+// Test case to verify envelope listing applies the user and date filters and returns a next page token.
+func TestClient_GetEnvelopes(t *testing.T) {
+	t.Run("successfully retrieves a page of envelopes", func(t *testing.T) {
+		since := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+		mockResponse := readMockResponse("envelopes.json")
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, getEnvelopesTest, r.URL.Path)
+			assert.Equal(t, test.MockUserID, r.URL.Query().Get("user_id"))
+			assert.Equal(t, "2025-04-01T00:00:00Z", r.URL.Query().Get("from_date"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(mockResponse))
+		}))
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		envelopes, nextToken, _, err := c.GetEnvelopes(context.Background(), test.MockUserID, since, client.PageOptions{})
+
+		require.NoError(t, err)
+		assert.Len(t, envelopes, 3)
+		assert.NotEmpty(t, nextToken)
+	})
+}
+
+// Test case to verify retrieval and deletion of a user's cloud storage connections.
+func TestClient_UserCloudStorage(t *testing.T) {
+	t.Run("successfully retrieves cloud storage providers", func(t *testing.T) {
+		mockResponse := readMockResponse("cloud_storage.json")
+		testServer := createTestServer(t, mockResponse, getUserCloudStorageTest, http.MethodGet)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		providers, _, err := c.GetUserCloudStorage(context.Background(), test.MockUserID)
+
+		require.NoError(t, err)
+		require.Len(t, providers, 2)
+		assert.Equal(t, "Box", providers[0].Service)
+		assert.Equal(t, "136", providers[0].ServiceId)
+	})
+
+	t.Run("successfully deletes a cloud storage connection", func(t *testing.T) {
+		testServer := createTestServer(t, "{}", deleteUserCloudStorageTest, http.MethodDelete)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		_, err := c.DeleteUserCloudStorage(context.Background(), test.MockUserID, "136")
+
+		require.NoError(t, err)
+	})
+}
+
+// Test case to verify retrieval of the account's permission profiles.
+func TestClient_GetPermissionProfiles(t *testing.T) {
+	t.Run("successfully retrieves permission profiles", func(t *testing.T) {
+		mockResponse := readMockResponse("permission_profiles.json")
+		testServer := createTestServer(t, mockResponse, getPermissionProfilesTest, http.MethodGet)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		profiles, _, err := c.GetPermissionProfiles(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, profiles, 2)
+		assert.Equal(t, "DS Admin", profiles[0].PermissionProfileName)
+		assert.Equal(t, "true", profiles[0].Settings["canManageAccount"])
+	})
+}
+
+// Test case to verify a user's permission profile is updated with a PUT on the user.
+func TestClient_UpdateUser(t *testing.T) {
+	t.Run("successfully updates the permission profile", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, getUserDetailsTest, r.URL.Path)
+			assert.Equal(t, http.MethodPut, r.Method)
+
+			var body client.UserUpdate
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "1002", body.PermissionProfileId)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte("{}"))
+		}))
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		_, err := c.UpdateUser(context.Background(), test.MockUserID, client.UserUpdate{PermissionProfileId: "1002"})
+
+		require.NoError(t, err)
+	})
+}
+
+// Test case to verify a custom permission profile is created with its settings.
+func TestClient_CreatePermissionProfile(t *testing.T) {
+	t.Run("successfully creates a permission profile", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, getPermissionProfilesTest, r.URL.Path)
+			assert.Equal(t, http.MethodPost, r.Method)
+
+			var body client.PermissionProfileCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "Contract Managers", body.PermissionProfileName)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"permissionProfileId": "2001", "permissionProfileName": "Contract Managers"}`))
+		}))
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		profile, _, err := c.CreatePermissionProfile(context.Background(), client.PermissionProfileCreateRequest{
+			PermissionProfileName: "Contract Managers",
+			Settings:              map[string]interface{}{"canSendEnvelope": "true"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "2001", profile.PermissionProfileId)
+	})
+}
+
+// Test case to verify a permission profile is deleted.
+func TestClient_DeletePermissionProfile(t *testing.T) {
+	t.Run("successfully deletes a permission profile", func(t *testing.T) {
+		testServer := createTestServer(t, "{}", deletePermissionProfileTest, http.MethodDelete)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		_, err := c.DeletePermissionProfile(context.Background(), "2001")
+
+		require.NoError(t, err)
+	})
+}
+
+// Test case to verify the users of a permission profile are counted from that profile only.
+func TestClient_HasPermissionProfileUsers(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, getPermissionProfilesTest, r.URL.Path)
+		assert.Equal(t, "user_count", r.URL.Query().Get("include"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"permissionProfiles": [
+			{"permissionProfileId": "1002", "permissionProfileName": "DocuSign Sender", "userCount": "12"},
+			{"permissionProfileId": "2001", "permissionProfileName": "Contract Reviewers", "userCount": "0"},
+			{"permissionProfileId": "2002", "permissionProfileName": "Legal Senders", "userCount": "1"}
+		]}`))
+	}))
+
+	defer testServer.Close()
+
+	c := createClient(testServer.URL)
+
+	t.Run("reports assigned users", func(t *testing.T) {
+		assigned, _, err := c.HasPermissionProfileUsers(context.Background(), "2002")
+
+		require.NoError(t, err)
+		assert.True(t, assigned)
+	})
+
+	t.Run("ignores the users of other profiles", func(t *testing.T) {
+		assigned, _, err := c.HasPermissionProfileUsers(context.Background(), "2001")
+
+		require.NoError(t, err)
+		assert.False(t, assigned)
+	})
+
+	t.Run("reports unknown profiles as unassigned", func(t *testing.T) {
+		assigned, _, err := c.HasPermissionProfileUsers(context.Background(), "9999")
+
+		require.NoError(t, err)
+		assert.False(t, assigned)
+	})
+}
+
+// Test case to verify the raw user settings are fetched.
+func TestClient_GetUserSettings(t *testing.T) {
+	t.Run("successfully gets user settings", func(t *testing.T) {
+		testServer := createTestServer(t, `{"bulkSend": "true", "accountManagementGranular": {"canManageUsers": "false"}}`, userSettingsTest, http.MethodGet)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		settings, _, err := c.GetUserSettings(context.Background(), test.MockUserID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "true", settings["bulkSend"])
+		assert.Equal(t, map[string]interface{}{"canManageUsers": "false"}, settings["accountManagementGranular"])
+	})
+}
+
+// Test case to verify user settings are updated with a partial settings document.
+func TestClient_UpdateUserSettings(t *testing.T) {
+	t.Run("successfully updates user settings", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, userSettingsTest, r.URL.Path)
+			assert.Equal(t, http.MethodPut, r.Method)
+
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]interface{}{"bulkSend": "true"}, body)
+
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		_, err := c.UpdateUserSettings(context.Background(), test.MockUserID, map[string]interface{}{"bulkSend": "true"})
+
+		require.NoError(t, err)
+	})
+}
+
+// Test case to verify users are added to and removed from a group.
+func TestClient_GroupUsers(t *testing.T) {
+	for _, method := range []string{http.MethodPut, http.MethodDelete} {
+		t.Run(method, func(t *testing.T) {
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, getGroupUsersTest, r.URL.Path)
+				assert.Equal(t, method, r.Method)
+
+				var body client.GroupUsersRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, []client.UserReference{{UserId: test.MockUserID}}, body.Users)
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"users": [{"userId": "u1", "userName": "Test User"}]}`))
+			}))
+
+			defer testServer.Close()
+
+			c := createClient(testServer.URL)
+			update := c.AddGroupUsers
+			if method == http.MethodDelete {
+				update = c.RemoveGroupUsers
+			}
+			response, _, err := update(context.Background(), test.MockGroupID, []string{test.MockUserID})
+
+			require.NoError(t, err)
+			require.Len(t, response.Users, 1)
+			assert.Nil(t, response.Users[0].ErrorDetails)
+		})
+	}
+}
+
+// Test case to verify a group is created with its permission profile.
+func TestClient_CreateGroup(t *testing.T) {
+	t.Run("successfully creates a group", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, getGroupsTest, r.URL.Path)
+			assert.Equal(t, http.MethodPost, r.Method)
+
+			var body client.CreateGroupsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []client.GroupCreate{{GroupName: "Legal", PermissionProfileId: "1002"}}, body.Groups)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"groups": [{"groupId": "g9", "groupName": "Legal", "groupType": "customGroup"}]}`))
+		}))
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		group, _, err := c.CreateGroup(context.Background(), client.GroupCreate{GroupName: "Legal", PermissionProfileId: "1002"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "g9", group.GroupId)
+	})
+
+	t.Run("reports group errors", func(t *testing.T) {
+		testServer := createTestServer(t, `{"groups": [{"groupName": "Legal", "errorDetails": {"errorCode": "GROUP_ALREADY_EXISTS", "message": "Group already exists."}}]}`, getGroupsTest, http.MethodPost)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		_, _, err := c.CreateGroup(context.Background(), client.GroupCreate{GroupName: "Legal"})
+
+		require.ErrorContains(t, err, "GROUP_ALREADY_EXISTS")
+	})
+}
+
+// Test case to verify a group is deleted by ID.
+func TestClient_DeleteGroup(t *testing.T) {
+	t.Run("successfully deletes a group", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, getGroupsTest, r.URL.Path)
+			assert.Equal(t, http.MethodDelete, r.Method)
+
+			var body client.DeleteGroupsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []client.GroupReference{{GroupId: "g9"}}, body.Groups)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"groups": [{"groupId": "g9"}]}`))
+		}))
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		_, err := c.DeleteGroup(context.Background(), "g9")
+
+		require.NoError(t, err)
+	})
+}
+
+// Test case to verify brands are assigned to a group.
+func TestClient_AddGroupBrands(t *testing.T) {
+	t.Run("successfully assigns brands", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, getGroupBrandsTest, r.URL.Path)
+			assert.Equal(t, http.MethodPut, r.Method)
+
+			var body client.GroupBrandsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []client.BrandReference{{BrandId: "b1"}}, body.Brands)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}))
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		_, err := c.AddGroupBrands(context.Background(), test.MockGroupID, []string{"b1"})
+
+		require.NoError(t, err)
+	})
+}
+
+// Test case to verify the account brands and a group's brands are fetched, and brands are unassigned from a group.
+func TestClient_Brands(t *testing.T) {
+	t.Run("successfully gets brands", func(t *testing.T) {
+		mockResponse := readMockResponse("brands.json")
+		testServer := createTestServer(t, mockResponse, getBrandsTest, http.MethodGet)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		response, _, err := c.GetBrands(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, response.Brands, 2)
+		assert.Equal(t, "Acme", response.Brands[0].BrandName)
+		assert.Equal(t, []string{"en", "fr"}, response.Brands[0].BrandLanguages)
+		assert.Equal(t, "b1", response.SenderBrandIdDefault)
+	})
+
+	t.Run("successfully gets group brands", func(t *testing.T) {
+		testServer := createTestServer(t, `{"brandOptions": [{"brandId": "b1", "brandName": "Acme"}]}`, getGroupBrandsTest, http.MethodGet)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		brands, _, err := c.GetGroupBrands(context.Background(), test.MockGroupID)
+
+		require.NoError(t, err)
+		require.Len(t, brands, 1)
+		assert.Equal(t, "b1", brands[0].BrandId)
+	})
+
+	t.Run("successfully unassigns brands", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, getGroupBrandsTest, r.URL.Path)
+			assert.Equal(t, http.MethodDelete, r.Method)
+
+			var body client.GroupBrandsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []client.BrandReference{{BrandId: "b1"}}, body.Brands)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}))
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		_, err := c.RemoveGroupBrands(context.Background(), test.MockGroupID, []string{"b1"})
+
+		require.NoError(t, err)
+	})
+}
+
+// Test case to verify signing groups and their members are fetched.
+func TestClient_SigningGroups(t *testing.T) {
+	t.Run("successfully gets signing groups", func(t *testing.T) {
+		mockResponse := readMockResponse("signing_groups.json")
+		testServer := createTestServer(t, mockResponse, getSigningGroupsTest, http.MethodGet)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		signingGroups, _, err := c.GetSigningGroups(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, signingGroups, 2)
+		assert.Equal(t, "sg1", signingGroups[0].SigningGroupId)
+		assert.Equal(t, "legal-signers@example.com", signingGroups[0].GroupEmail)
+	})
+
+	t.Run("successfully gets signing group users", func(t *testing.T) {
+		mockResponse := readMockResponse("signing_group_users.json")
+		testServer := createTestServer(t, mockResponse, getSigningGroupUsersTest, http.MethodGet)
+
+		defer testServer.Close()
+
+		c := createClient(testServer.URL)
+		users, _, err := c.GetSigningGroupUsers(context.Background(), "sg1")
+
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, "alice@example.com", users[0].Email)
+	})
+}
+
+// Test case to verify members are added to and removed from a signing group.
+func TestClient_UpdateSigningGroupUsers(t *testing.T) {
+	for _, method := range []string{http.MethodPut, http.MethodDelete} {
+		t.Run(method, func(t *testing.T) {
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, getSigningGroupUsersTest, r.URL.Path)
+				assert.Equal(t, method, r.Method)
+
+				var body client.SigningGroupUsersRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, []client.SigningGroupUser{{UserName: "Alice Smith", Email: "alice@example.com"}}, body.Users)
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"users": [{"userName": "Alice Smith", "email": "alice@example.com"}]}`))
+			}))
+
+			defer testServer.Close()
+
+			c := createClient(testServer.URL)
+			update := c.AddSigningGroupUsers
+			if method == http.MethodDelete {
+				update = c.RemoveSigningGroupUsers
+			}
+			response, _, err := update(context.Background(), "sg1", []client.SigningGroupUser{{UserName: "Alice Smith", Email: "alice@example.com"}})
+
+			require.NoError(t, err)
+			require.Len(t, response.Users, 1)
+			assert.Nil(t, response.Users[0].ErrorDetails)
+		})
+	}
+}
+
+// Test case to verify users are looked up by email.
+func TestClient_GetUsersByEmail(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, getUsersTest, r.URL.Path)
+		assert.Equal(t, "alice@example.com", r.URL.Query().Get("email"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"users": [{"userId": "u1", "email": "alice@example.com", "userStatus": "Active"}]}`))
+	}))
+
+	defer testServer.Close()
+
+	c := createClient(testServer.URL)
+	users, _, err := c.GetUsersByEmail(context.Background(), "alice@example.com")
+
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "u1", users[0].UserId)
+}
+
+// Test case to verify the account information is fetched.
+func TestClient_GetAccount(t *testing.T) {
+	testServer := createTestServer(t, `{"accountIdGuid": "account123", "accountName": "Acme", "planName": "Business Pro"}`, "/restapi/v2.1/accounts/account123", http.MethodGet)
+
+	defer testServer.Close()
+
+	c := createClient(testServer.URL)
+	account, _, err := c.GetAccount(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "account123", account.AccountIdGuid)
+	assert.Equal(t, "Acme", account.AccountName)
+}
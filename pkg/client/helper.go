@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/ratelimit"
@@ -99,11 +100,24 @@ func preparePagedRequest(baseURL *url.URL, endpoint string, options PageOptions)
 }
 
 // GetNextToken calculates the token for the next page based on the response.
+// EndPosition is the zero-based index of the last item returned, so the last page ends at TotalSetSize-1.
 func getNextToken(responsePage Page) string {
-	if responsePage.EndPosition < responsePage.TotalSetSize {
+	if responsePage.ResultSetSize > 0 && responsePage.EndPosition+1 < responsePage.TotalSetSize {
 		return encodePageToken(&pageToken{
 			StartPosition: responsePage.EndPosition + 1,
 		})
 	}
 	return ""
 }
+
+// ParseTime parses a DocuSign timestamp, returning nil for empty values.
+func ParseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp %q: %w", value, err)
+	}
+	return &parsed, nil
+}
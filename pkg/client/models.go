@@ -1,5 +1,7 @@
 package client
 
+import "encoding/json"
+
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	TokenType    string `json:"token_type"`
@@ -10,6 +12,8 @@ type TokenResponse struct {
 type PageOptions struct {
 	PageSize  int
 	PageToken string
+	// AdditionalInfo asks GetUsers to include each user's settings, which makes every page a much larger response.
+	AdditionalInfo bool
 }
 
 type Page struct {
@@ -23,13 +27,24 @@ type pageToken struct {
 	StartPosition int `json:"start_position"`
 }
 
+type AccountInformation struct {
+	AccountIdGuid string `json:"accountIdGuid"`
+	AccountName   string `json:"accountName"`
+	PlanName      string `json:"planName"`
+}
+
 type User struct {
-	UserId     string `json:"userId"`
-	UserName   string `json:"userName"`
-	Email      string `json:"email"`
-	UserStatus string `json:"userStatus"`
-	IsAdmin    string `json:"isAdmin"`
-	Permission string `json:"permissionProfileName"`
+	UserId          string `json:"userId"`
+	UserName        string `json:"userName"`
+	Email           string `json:"email"`
+	UserStatus      string `json:"userStatus"`
+	IsAdmin         string `json:"isAdmin"`
+	Permission      string `json:"permissionProfileName"`
+	PermissionId    string `json:"permissionProfileId"`
+	LastLogin       string `json:"lastLogin"`
+	CreatedDateTime string `json:"createdDateTime"`
+	// UserSettings are the raw user settings, listed with additional_info.
+	UserSettings map[string]interface{} `json:"userSettings,omitempty"`
 }
 
 type UsersResponse struct {
@@ -38,10 +53,12 @@ type UsersResponse struct {
 }
 
 type Group struct {
-	GroupId    string `json:"groupId"`
-	GroupName  string `json:"groupName"`
-	GroupType  string `json:"groupType"`
-	UsersCount string `json:"usersCount"`
+	GroupId             string        `json:"groupId"`
+	GroupName           string        `json:"groupName"`
+	GroupType           string        `json:"groupType"`
+	UsersCount          string        `json:"usersCount"`
+	PermissionProfileId string        `json:"permissionProfileId,omitempty"`
+	ErrorDetails        *ErrorDetails `json:"errorDetails,omitempty"`
 }
 
 type GroupsResponse struct {
@@ -56,8 +73,29 @@ type UserDetail struct {
 	IsAdmin               string       `json:"isAdmin"`
 	UserStatus            string       `json:"userStatus"`
 	PermissionProfileName string       `json:"permissionProfileName"`
+	PermissionProfileId   string       `json:"permissionProfileId"`
 	UserSettings          UserSettings `json:"userSettings"`
 	GroupList             []Group      `json:"groupList"`
+
+	// RawUserSettings holds every setting returned by DocuSign, including the ones UserSettings does not declare.
+	RawUserSettings map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON decodes the user and keeps the raw user settings alongside the typed ones.
+func (u *UserDetail) UnmarshalJSON(data []byte) error {
+	type userDetail UserDetail
+	if err := json.Unmarshal(data, (*userDetail)(u)); err != nil {
+		return err
+	}
+
+	var raw struct {
+		UserSettings map[string]interface{} `json:"userSettings"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	u.RawUserSettings = raw.UserSettings
+	return nil
 }
 
 type UserSettings struct {
@@ -92,6 +130,15 @@ type AccountManagement struct {
 	CanManageAccountSettings         string `json:"canManageAccountSettings"`
 	CanManageReporting               string `json:"canManageReporting"`
 	CanManageAccountSecuritySettings string `json:"canManageAccountSecuritySettings"`
+	CanManageSharing                 string `json:"canManageSharing"`
+	CanManageSigningGroups           string `json:"canManageSigningGroups"`
+	CanManageGroupsButNotUsers       string `json:"canManageGroupsButNotUsers"`
+	CanManageConnect                 string `json:"canManageConnect"`
+	CanManageDocumentRetention       string `json:"canManageDocumentRetention"`
+	CanManageEnvelopeTransfer        string `json:"canManageEnvelopeTransfer"`
+	CanManageJointAgreements         string `json:"canManageJointAgreements"`
+	CanManageStamps                  string `json:"canManageStamps"`
+	CanViewUsers                     string `json:"canViewUsers"`
 }
 
 type EmailNotifications struct {
@@ -106,6 +153,11 @@ type NewUser struct {
 	UserSettings *UserSettings `json:"userSettings,omitempty"`
 }
 
+type UserUpdate struct {
+	PermissionProfileId string `json:"permissionProfileId,omitempty"`
+	UserStatus          string `json:"userStatus,omitempty"`
+}
+
 type CreateUsersRequest struct {
 	NewUsers []NewUser `json:"newUsers"`
 }
@@ -125,3 +177,196 @@ type UserCreationResponse struct {
 		} `json:"errorDetails,omitempty"`
 	} `json:"newUsers"`
 }
+
+type UserReference struct {
+	UserId string `json:"userId"`
+}
+
+type UsersCloseRequest struct {
+	Users []UserReference `json:"users"`
+}
+
+type GroupCreate struct {
+	GroupName           string `json:"groupName"`
+	PermissionProfileId string `json:"permissionProfileId,omitempty"`
+}
+
+type CreateGroupsRequest struct {
+	Groups []GroupCreate `json:"groups"`
+}
+
+type GroupReference struct {
+	GroupId string `json:"groupId"`
+}
+
+type DeleteGroupsRequest struct {
+	Groups []GroupReference `json:"groups"`
+}
+
+type BrandReference struct {
+	BrandId string `json:"brandId"`
+}
+
+type GroupBrandsRequest struct {
+	Brands []BrandReference `json:"brands"`
+}
+
+type Brand struct {
+	BrandId              string   `json:"brandId"`
+	BrandName            string   `json:"brandName"`
+	BrandCompany         string   `json:"brandCompany"`
+	IsSendingDefault     string   `json:"isSendingDefault"`
+	IsSigningDefault     string   `json:"isSigningDefault"`
+	DefaultBrandLanguage string   `json:"defaultBrandLanguage"`
+	BrandLanguages       []string `json:"brandLanguages"`
+}
+
+type BrandsResponse struct {
+	Brands                  []Brand `json:"brands"`
+	SenderBrandIdDefault    string  `json:"senderBrandIdDefault"`
+	RecipientBrandIdDefault string  `json:"recipientBrandIdDefault"`
+}
+
+type GroupBrandsResponse struct {
+	BrandOptions []Brand `json:"brandOptions"`
+}
+
+type SigningGroup struct {
+	SigningGroupId string             `json:"signingGroupId"`
+	GroupName      string             `json:"groupName"`
+	GroupType      string             `json:"groupType"`
+	GroupEmail     string             `json:"groupEmail"`
+	Created        string             `json:"created"`
+	Modified       string             `json:"modified"`
+	Users          []SigningGroupUser `json:"users,omitempty"`
+}
+
+type SigningGroupsResponse struct {
+	Groups []SigningGroup `json:"groups"`
+}
+
+type SigningGroupUser struct {
+	UserName     string        `json:"userName"`
+	Email        string        `json:"email"`
+	ErrorDetails *ErrorDetails `json:"errorDetails,omitempty"`
+}
+
+type SigningGroupUsersRequest struct {
+	Users []SigningGroupUser `json:"users"`
+}
+
+type SigningGroupUsersResponse struct {
+	Users []SigningGroupUser `json:"users"`
+}
+
+type GroupUsersRequest struct {
+	Users []UserReference `json:"users"`
+}
+
+type GroupUser struct {
+	UserId       string        `json:"userId"`
+	UserName     string        `json:"userName"`
+	ErrorDetails *ErrorDetails `json:"errorDetails,omitempty"`
+}
+
+type GroupUsersResponse struct {
+	Users []GroupUser `json:"users"`
+}
+
+type ErrorDetails struct {
+	ErrorCode string `json:"errorCode"`
+	Message   string `json:"message"`
+}
+
+type ClosedUser struct {
+	UserId       string        `json:"userId"`
+	UserStatus   string        `json:"userStatus"`
+	ErrorDetails *ErrorDetails `json:"errorDetails,omitempty"`
+}
+
+type UsersCloseResponse struct {
+	Users []ClosedUser `json:"users"`
+}
+
+type Envelope struct {
+	EnvelopeId            string `json:"envelopeId"`
+	Status                string `json:"status"`
+	SentDateTime          string `json:"sentDateTime"`
+	CompletedDateTime     string `json:"completedDateTime"`
+	VoidedDateTime        string `json:"voidedDateTime"`
+	StatusChangedDateTime string `json:"statusChangedDateTime"`
+}
+
+type EnvelopesResponse struct {
+	Envelopes []Envelope `json:"envelopes"`
+	Page
+}
+
+type PermissionProfile struct {
+	PermissionProfileId   string                 `json:"permissionProfileId"`
+	PermissionProfileName string                 `json:"permissionProfileName"`
+	ModifiedDateTime      string                 `json:"modifiedDateTime"`
+	UserCount             string                 `json:"userCount,omitempty"`
+	Settings              map[string]interface{} `json:"settings"`
+}
+
+type PermissionProfileCreateRequest struct {
+	PermissionProfileName string                 `json:"permissionProfileName"`
+	Settings              map[string]interface{} `json:"settings,omitempty"`
+}
+
+type PermissionProfilesResponse struct {
+	PermissionProfiles []PermissionProfile `json:"permissionProfiles"`
+}
+
+type CloudStorageProvider struct {
+	Service           string        `json:"service"`
+	ServiceId         string        `json:"serviceId"`
+	AuthenticationUrl string        `json:"authenticationUrl"`
+	RedirectUrl       string        `json:"redirectUrl"`
+	ErrorDetails      *ErrorDetails `json:"errorDetails,omitempty"`
+}
+
+type CloudStorageProvidersResponse struct {
+	StorageProviders []CloudStorageProvider `json:"storageProviders"`
+}
+
+type OrganizationUsersResponse struct {
+	Users []OrganizationUser `json:"users"`
+}
+
+type OrganizationUser struct {
+	ID         string                 `json:"id"`
+	UserName   string                 `json:"user_name"`
+	Email      string                 `json:"email"`
+	UserStatus string                 `json:"user_status"`
+	Identities []OrganizationIdentity `json:"identities"`
+}
+
+type OrganizationIdentity struct {
+	ID          string `json:"id"`
+	ProviderID  string `json:"provider_id"`
+	UserID      string `json:"user_id"`
+	ImmutableID string `json:"immutable_id"`
+}
+
+type IdentityProvidersResponse struct {
+	IdentityProviders []IdentityProvider `json:"identity_providers"`
+}
+
+type IdentityProvider struct {
+	ID                 string `json:"id"`
+	FriendlyName       string `json:"friendly_name"`
+	Type               string `json:"type"`
+	AutoProvisionUsers bool   `json:"auto_provision_users"`
+}
+
+type ReservedDomainsResponse struct {
+	ReservedDomains []ReservedDomain `json:"reserved_domains"`
+}
+
+type ReservedDomain struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	HostName string `json:"host_name"`
+}
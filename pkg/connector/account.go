@@ -0,0 +1,303 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	"github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+// Entitlement value representing DocuSign account administration.
+const (
+	entitlementAccountAdministrator = "administrator"
+)
+
+// adminPermissionProfileName is the built-in profile that makes its users account administrators.
+const adminPermissionProfileName = "DS Admin"
+
+// AccountClient defines the methods required to sync the account and provision its administrators.
+type AccountClient interface {
+	PermissionProfileClient
+	GetAccount(ctx context.Context) (*client.AccountInformation, annotations.Annotations, error)
+	GetGroups(ctx context.Context, options client.PageOptions) ([]client.Group, string, annotations.Annotations, error)
+}
+
+// accountBuilder syncs the DocuSign account with an "administrator" entitlement held by every account administrator.
+// The built-in Administrators group is granted the entitlement too, expanding to the group members.
+// Administrator rights come from the permission profile, so provisioning moves users to or from the DS Admin profile.
+type accountBuilder struct {
+	resourceType *v2.ResourceType
+	client       AccountClient
+	profiles     *permissionProfileBuilder
+}
+
+// ResourceType returns the Baton resource type handled by this builder.
+func (a *accountBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return accountResourceType
+}
+
+// List returns the account the connector is configured for.
+func (a *accountBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	account, annos, err := a.client.GetAccount(ctx)
+	if err != nil {
+		return nil, "", annos, fmt.Errorf("docusign-connector: failed to get account: %w", err)
+	}
+
+	displayName := account.AccountName
+	if displayName == "" {
+		displayName = account.AccountIdGuid
+	}
+
+	accountResource, err := resource.NewResource(displayName, accountResourceType, account.AccountIdGuid)
+	if err != nil {
+		return nil, "", annos, fmt.Errorf("failed to create account resource: %w", err)
+	}
+
+	return []*v2.Resource{accountResource}, "", annos, nil
+}
+
+// Entitlements returns the "administrator" entitlement of the account, grantable to users.
+func (a *accountBuilder) Entitlements(ctx context.Context, accountResource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	ent := entitlement.NewPermissionEntitlement(
+		accountResource,
+		entitlementAccountAdministrator,
+		entitlement.WithGrantableTo(userResourceType),
+		entitlement.WithDisplayName(fmt.Sprintf("%s Administrator", accountResource.DisplayName)),
+		entitlement.WithDescription(fmt.Sprintf("Administers the %s DocuSign account, with full control over its users, settings and security", accountResource.DisplayName)),
+	)
+	return []*v2.Entitlement{ent}, "", annotations.Annotations{}, nil
+}
+
+// Grants pages through the account users and grants "administrator" to the ones DocuSign flags as administrators.
+// The first page also grants "administrator" to the Administrators group, so its members hold it through the group.
+func (a *accountBuilder) Grants(ctx context.Context, accountResource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	bag, pageToken, err := parsePageToken(pToken.Token, &v2.ResourceId{ResourceType: userResourceType.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	var grants []*v2.Grant
+	annos := annotations.Annotations{}
+	if pToken.Token == "" {
+		adminGroup, groupAnnos, err := a.adminGroup(ctx)
+		annos = append(annos, groupAnnos...)
+		if err != nil {
+			return nil, "", annos, err
+		}
+		if adminGroup != nil {
+			grants = append(grants, newAdminGroupAccountGrant(accountResource, adminGroup))
+		}
+	}
+
+	users, nextPageToken, usersAnnos, err := a.client.GetUsers(ctx, client.PageOptions{
+		PageSize:  pToken.Size,
+		PageToken: pageToken,
+	})
+	annos = append(annos, usersAnnos...)
+	if err != nil {
+		return nil, "", annos, fmt.Errorf("docusign-connector: failed to list users: %w", err)
+	}
+
+	for _, user := range users {
+		if !strings.EqualFold(user.IsAdmin, "true") {
+			continue
+		}
+		grants = append(grants, grant.NewGrant(
+			accountResource,
+			entitlementAccountAdministrator,
+			makeUserSubjectID(user.UserId),
+			grant.WithGrantMetadata(map[string]interface{}{
+				"user_id":                 user.UserId,
+				"username":                user.UserName,
+				"user_status":             user.UserStatus,
+				"permission_profile_id":   user.PermissionId,
+				"permission_profile_name": user.Permission,
+			}),
+		))
+	}
+
+	var outToken string
+	if nextPageToken != "" {
+		outToken, err = bag.NextToken(nextPageToken)
+		if err != nil {
+			return nil, "", annos, err
+		}
+	}
+	return grants, outToken, annos, nil
+}
+
+// Grant makes the user an account administrator by moving it to the DS Admin permission profile.
+// The returned grant records the profile the user was moved off in its metadata.
+func (a *accountBuilder) Grant(ctx context.Context, principal *v2.Resource, ent *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	if principal.Id.ResourceType != userResourceType.Id {
+		return nil, nil, fmt.Errorf("docusign-connector: only users can be account administrators")
+	}
+
+	userID := principal.Id.Resource
+	detail, annos, err := a.client.GetUserDetails(ctx, userID)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to get user %s: %w", userID, err)
+	}
+	if isUserAdmin(detail) {
+		annos.Update(&v2.GrantAlreadyExists{})
+		return nil, annos, nil
+	}
+
+	adminProfile, profileAnnos, err := a.adminPermissionProfile(ctx)
+	annos = append(annos, profileAnnos...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	previous, assignAnnos, err := a.profiles.assignPermissionProfile(ctx, detail, adminProfile.PermissionProfileId)
+	annos = append(annos, assignAnnos...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	g := grant.NewGrant(
+		ent.Resource,
+		entitlementAccountAdministrator,
+		principal.Id,
+		grant.WithGrantMetadata(map[string]interface{}{
+			"user_id":                          userID,
+			"username":                         detail.UserName,
+			"permission_profile_id":            adminProfile.PermissionProfileId,
+			"permission_profile_name":          adminProfile.PermissionProfileName,
+			"previous_permission_profile_id":   previous.PermissionProfileId,
+			"previous_permission_profile_name": previous.PermissionProfileName,
+		}),
+	)
+
+	return []*v2.Grant{g}, annos, nil
+}
+
+// Revoke removes the user's administrator rights by moving it to the fallback permission profile.
+// Removing the last active account administrator is refused.
+func (a *accountBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	if g.Principal.Id.ResourceType != userResourceType.Id {
+		return nil, fmt.Errorf("docusign-connector: only users can be account administrators")
+	}
+
+	fallbackProfileID := a.profiles.fallbackProfileID
+	if fallbackProfileID == "" {
+		return nil, fmt.Errorf("docusign-connector: every user needs a permission profile, configure a fallback permission profile to revoke administrator rights")
+	}
+
+	userID := g.Principal.Id.Resource
+	detail, annos, err := a.client.GetUserDetails(ctx, userID)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to get user %s: %w", userID, err)
+	}
+	if !isUserAdmin(detail) {
+		annos.Update(&v2.GrantAlreadyRevoked{})
+		return annos, nil
+	}
+
+	profiles, profileAnnos, err := a.client.GetPermissionProfiles(ctx)
+	annos = append(annos, profileAnnos...)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to list permission profiles: %w", err)
+	}
+	for i := range profiles {
+		if profiles[i].PermissionProfileId == fallbackProfileID && isAdminPermissionProfile(&profiles[i]) {
+			return annos, fmt.Errorf("docusign-connector: the fallback permission profile %s grants administrator rights", profiles[i].PermissionProfileName)
+		}
+	}
+
+	_, assignAnnos, err := a.profiles.assignPermissionProfile(ctx, detail, fallbackProfileID)
+	annos = append(annos, assignAnnos...)
+	if err != nil {
+		return annos, err
+	}
+
+	return annos, nil
+}
+
+// adminPermissionProfile returns the DS Admin permission profile, or another administrator profile when it was renamed.
+func (a *accountBuilder) adminPermissionProfile(ctx context.Context) (*client.PermissionProfile, annotations.Annotations, error) {
+	profiles, annos, err := a.client.GetPermissionProfiles(ctx)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to list permission profiles: %w", err)
+	}
+
+	var adminProfile *client.PermissionProfile
+	for i := range profiles {
+		if !isAdminPermissionProfile(&profiles[i]) {
+			continue
+		}
+		if strings.EqualFold(profiles[i].PermissionProfileName, adminPermissionProfileName) {
+			return &profiles[i], annos, nil
+		}
+		if adminProfile == nil {
+			adminProfile = &profiles[i]
+		}
+	}
+	if adminProfile == nil {
+		return nil, annos, fmt.Errorf("docusign-connector: no administrator permission profile found")
+	}
+	return adminProfile, annos, nil
+}
+
+// adminGroup pages through the groups to find the built-in Administrators group, or returns nil when there is none.
+func (a *accountBuilder) adminGroup(ctx context.Context) (*client.Group, annotations.Annotations, error) {
+	annos := annotations.Annotations{}
+	pageToken := ""
+	for {
+		groups, nextPageToken, pageAnnos, err := a.client.GetGroups(ctx, client.PageOptions{
+			PageSize:  client.DefaultPageSize,
+			PageToken: pageToken,
+		})
+		annos = append(annos, pageAnnos...)
+		if err != nil {
+			return nil, annos, fmt.Errorf("docusign-connector: failed to list groups: %w", err)
+		}
+
+		for i := range groups {
+			if groups[i].GroupType == groupTypeAdmin {
+				return &groups[i], annos, nil
+			}
+		}
+
+		if nextPageToken == "" {
+			return nil, annos, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// newAdminGroupAccountGrant grants the Administrators group the "administrator" entitlement of the account.
+// The grant expands to the group members, and is immutable because DocuSign manages the group.
+func newAdminGroupAccountGrant(accountResource *v2.Resource, group *client.Group) *v2.Grant {
+	groupResourceID := &v2.ResourceId{ResourceType: groupResourceType.Id, Resource: group.GroupId}
+	return grant.NewGrant(
+		accountResource,
+		entitlementAccountAdministrator,
+		groupResourceID,
+		grant.WithAnnotation(&v2.GrantExpandable{
+			EntitlementIds: []string{entitlement.NewEntitlementID(&v2.Resource{Id: groupResourceID}, entitlementGroupMember)},
+		}),
+		grant.WithAnnotation(&v2.GrantImmutable{}),
+		grant.WithGrantMetadata(map[string]interface{}{
+			"source_group_id":   group.GroupId,
+			"source_group_name": group.GroupName,
+		}),
+	)
+}
+
+// newAccountBuilder constructs an accountBuilder with the provided API client.
+// fallbackProfileID is the profile administrators are moved to when their rights are revoked.
+func newAccountBuilder(client AccountClient, fallbackProfileID string) *accountBuilder {
+	return &accountBuilder{
+		resourceType: accountResourceType,
+		client:       client,
+		profiles:     newPermissionProfileBuilder(client, fallbackProfileID, nil),
+	}
+}
@@ -0,0 +1,167 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAccountClient implements AccountClient on top of mockPermissionProfileClient.
+type mockAccountClient struct {
+	*mockPermissionProfileClient
+}
+
+func (m *mockAccountClient) GetAccount(ctx context.Context) (*client.AccountInformation, annotations.Annotations, error) {
+	return &client.AccountInformation{AccountIdGuid: "account123", AccountName: "Acme"}, nil, nil
+}
+
+func (m *mockAccountClient) GetGroups(ctx context.Context, options client.PageOptions) ([]client.Group, string, annotations.Annotations, error) {
+	return []client.Group{
+		{GroupId: "g1", GroupName: "Administrators", GroupType: groupTypeAdmin},
+		{GroupId: "g2", GroupName: "Everyone", GroupType: groupTypeEveryone},
+	}, "", nil, nil
+}
+
+func newTestAccountGrant(userID string) *v2.Grant {
+	accountResource := &v2.Resource{Id: &v2.ResourceId{ResourceType: accountResourceType.Id, Resource: "account123"}}
+	return &v2.Grant{
+		Entitlement: &v2.Entitlement{Resource: accountResource},
+		Principal:   &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: userID}},
+	}
+}
+
+// TestAccountBuilder_Sync verifies the account is listed with an "administrator" entitlement granted to admins only,
+// and to the Administrators group.
+func TestAccountBuilder_Sync(t *testing.T) {
+	builder := newAccountBuilder(&mockAccountClient{&mockPermissionProfileClient{
+		users: []client.User{
+			{UserId: "admin1", UserName: "Ada", IsAdmin: "True", UserStatus: "Active", PermissionId: "1001", Permission: "DS Admin"},
+			{UserId: "u1", UserName: "Jane", IsAdmin: "False", UserStatus: "Active", PermissionId: "1002", Permission: "DocuSign Sender"},
+		},
+	}}, "")
+
+	resources, _, _, err := builder.List(context.Background(), nil, &pagination.Token{})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "account123", resources[0].Id.Resource)
+	assert.Equal(t, "Acme", resources[0].DisplayName)
+
+	ents, _, _, err := builder.Entitlements(context.Background(), resources[0], nil)
+	require.NoError(t, err)
+	require.Len(t, ents, 1)
+	assert.Equal(t, entitlementAccountAdministrator, ents[0].Slug)
+
+	grants, nextToken, _, err := builder.Grants(context.Background(), resources[0], &pagination.Token{})
+	require.NoError(t, err)
+	assert.Empty(t, nextToken)
+	require.Len(t, grants, 2)
+
+	// The Administrators group holds the entitlement, which expands to its members.
+	assert.Equal(t, groupResourceType.Id, grants[0].Principal.Id.ResourceType)
+	assert.Equal(t, "g1", grants[0].Principal.Id.Resource)
+	expandable := &v2.GrantExpandable{}
+	grantAnnos := annotations.Annotations(grants[0].Annotations)
+	ok, err := grantAnnos.Pick(expandable)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []string{"group:g1:member"}, expandable.EntitlementIds)
+	assert.True(t, grantAnnos.Contains(&v2.GrantImmutable{}))
+
+	assert.Equal(t, "admin1", grants[1].Principal.Id.Resource)
+}
+
+// TestAccountBuilder_Grant verifies granting moves the user to the DS Admin profile.
+func TestAccountBuilder_Grant(t *testing.T) {
+	profileClient := &mockPermissionProfileClient{
+		profiles: readMockPermissionProfiles(t),
+		details: map[string]*client.UserDetail{
+			"u1":     {UserID: "u1", IsAdmin: "False", PermissionProfileId: "1002", PermissionProfileName: "DocuSign Sender"},
+			"admin1": {UserID: "admin1", IsAdmin: "True", PermissionProfileId: "1001"},
+		},
+	}
+	builder := newAccountBuilder(&mockAccountClient{profileClient}, "1002")
+	accountGrant := newTestAccountGrant("u1")
+
+	grants, _, err := builder.Grant(context.Background(), accountGrant.Principal, accountGrant.Entitlement)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, "1001", profileClient.updates["u1"])
+
+	metadata := &v2.GrantMetadata{}
+	grantAnnos := annotations.Annotations(grants[0].Annotations)
+	_, err = grantAnnos.Pick(metadata)
+	require.NoError(t, err)
+	assert.Equal(t, "1002", metadata.Metadata.AsMap()["previous_permission_profile_id"])
+
+	adminGrant := newTestAccountGrant("admin1")
+	grants, annos, err := builder.Grant(context.Background(), adminGrant.Principal, adminGrant.Entitlement)
+	require.NoError(t, err)
+	assert.Empty(t, grants)
+	assert.True(t, annos.Contains(&v2.GrantAlreadyExists{}))
+	assert.NotContains(t, profileClient.updates, "admin1")
+}
+
+// TestAccountBuilder_Revoke verifies revoking moves the administrator to the fallback profile and the last admin is protected.
+func TestAccountBuilder_Revoke(t *testing.T) {
+	newProfileClient := func(otherAdminStatus string) *mockPermissionProfileClient {
+		return &mockPermissionProfileClient{
+			profiles: readMockPermissionProfiles(t),
+			users: []client.User{
+				{UserId: "admin1", IsAdmin: "True", UserStatus: "Active"},
+				{UserId: "admin2", IsAdmin: "True", UserStatus: otherAdminStatus},
+			},
+			details: map[string]*client.UserDetail{
+				"admin1": {UserID: "admin1", IsAdmin: "True", PermissionProfileId: "1001"},
+				"u1":     {UserID: "u1", IsAdmin: "False", PermissionProfileId: "1002"},
+			},
+		}
+	}
+
+	t.Run("moves the administrator to the fallback profile", func(t *testing.T) {
+		profileClient := newProfileClient("Active")
+		builder := newAccountBuilder(&mockAccountClient{profileClient}, "1002")
+
+		_, err := builder.Revoke(context.Background(), newTestAccountGrant("admin1"))
+		require.NoError(t, err)
+		assert.Equal(t, "1002", profileClient.updates["admin1"])
+	})
+
+	t.Run("refuses to remove the last administrator", func(t *testing.T) {
+		profileClient := newProfileClient("Closed")
+		builder := newAccountBuilder(&mockAccountClient{profileClient}, "1002")
+
+		_, err := builder.Revoke(context.Background(), newTestAccountGrant("admin1"))
+		require.Error(t, err)
+		assert.Empty(t, profileClient.updates)
+	})
+
+	t.Run("refuses an administrator fallback profile", func(t *testing.T) {
+		profileClient := newProfileClient("Active")
+		builder := newAccountBuilder(&mockAccountClient{profileClient}, "1001")
+
+		_, err := builder.Revoke(context.Background(), newTestAccountGrant("admin1"))
+		require.Error(t, err)
+		assert.Empty(t, profileClient.updates)
+	})
+
+	t.Run("reports users who are not administrators", func(t *testing.T) {
+		builder := newAccountBuilder(&mockAccountClient{newProfileClient("Active")}, "1002")
+
+		annos, err := builder.Revoke(context.Background(), newTestAccountGrant("u1"))
+		require.NoError(t, err)
+		assert.True(t, annos.Contains(&v2.GrantAlreadyRevoked{}))
+	})
+
+	t.Run("requires a fallback profile", func(t *testing.T) {
+		builder := newAccountBuilder(&mockAccountClient{newProfileClient("Active")}, "")
+
+		_, err := builder.Revoke(context.Background(), newTestAccountGrant("admin1"))
+		require.Error(t, err)
+	})
+}
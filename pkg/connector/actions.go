@@ -0,0 +1,238 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	config "github.com/conductorone/baton-sdk/pb/c1/config/v1"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Custom action names supported by the connector.
+const (
+	actionCloseDormantUsers = "close_dormant_users"
+)
+
+// ActionClient defines the DocuSign API operations used by custom actions.
+type ActionClient interface {
+	GetUsers(ctx context.Context, options client.PageOptions) ([]client.User, string, annotations.Annotations, error)
+	CloseUsers(ctx context.Context, userIDs []string) (*client.UsersCloseResponse, annotations.Annotations, error)
+}
+
+// actionResult records the outcome of an invoked action so its status can be queried later.
+type actionResult struct {
+	name     string
+	status   v2.BatonActionStatus
+	response *structpb.Struct
+}
+
+// actionManager implements the connector's custom actions.
+type actionManager struct {
+	client   ActionClient
+	dormancy *dormancyChecker
+
+	mu      sync.Mutex
+	results map[string]*actionResult
+}
+
+// newActionManager creates an actionManager. dormancy is nil when dormant user detection is disabled.
+func newActionManager(client ActionClient, dormancy *dormancyChecker) *actionManager {
+	return &actionManager{
+		client:   client,
+		dormancy: dormancy,
+		results:  make(map[string]*actionResult),
+	}
+}
+
+// RegisterActionManager exposes the connector's custom actions to the Baton SDK.
+func (d *Connector) RegisterActionManager(ctx context.Context) (connectorbuilder.CustomActionManager, error) {
+	return d.actions, nil
+}
+
+// ListActionSchemas returns the schemas of all supported custom actions.
+func (a *actionManager) ListActionSchemas(ctx context.Context) ([]*v2.BatonActionSchema, annotations.Annotations, error) {
+	return []*v2.BatonActionSchema{closeDormantUsersSchema}, nil, nil
+}
+
+// GetActionSchema returns the schema of the named custom action.
+func (a *actionManager) GetActionSchema(ctx context.Context, name string) (*v2.BatonActionSchema, annotations.Annotations, error) {
+	switch name {
+	case actionCloseDormantUsers:
+		return closeDormantUsersSchema, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown action: %s", name)
+	}
+}
+
+// InvokeAction runs the named custom action synchronously and records its result.
+func (a *actionManager) InvokeAction(ctx context.Context, name string, args *structpb.Struct) (string, v2.BatonActionStatus, *structpb.Struct, annotations.Annotations, error) {
+	var (
+		response *structpb.Struct
+		annos    annotations.Annotations
+		err      error
+	)
+
+	switch name {
+	case actionCloseDormantUsers:
+		dryRun := true
+		if value, ok := args.GetFields()["dry_run"]; ok {
+			dryRun = value.GetBoolValue()
+		}
+		response, annos, err = a.closeDormantUsers(ctx, dryRun)
+	default:
+		return "", v2.BatonActionStatus_BATON_ACTION_STATUS_UNKNOWN, nil, nil, fmt.Errorf("unknown action: %s", name)
+	}
+
+	status := v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE
+	if err != nil {
+		status = v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED
+	}
+
+	id := fmt.Sprintf("%s-%d", name, time.Now().UnixNano())
+	a.mu.Lock()
+	a.results[id] = &actionResult{name: name, status: status, response: response}
+	a.mu.Unlock()
+
+	return id, status, response, annos, err
+}
+
+// GetActionStatus returns the recorded result of a previously invoked action.
+func (a *actionManager) GetActionStatus(ctx context.Context, id string) (v2.BatonActionStatus, string, *structpb.Struct, annotations.Annotations, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result, ok := a.results[id]
+	if !ok {
+		return v2.BatonActionStatus_BATON_ACTION_STATUS_UNKNOWN, "", nil, nil, fmt.Errorf("unknown action id: %s", id)
+	}
+	return result.status, result.name, result.response, nil, nil
+}
+
+// closeDormantUsers finds every dormant user in the account and closes them unless dryRun is set.
+func (a *actionManager) closeDormantUsers(ctx context.Context, dryRun bool) (*structpb.Struct, annotations.Annotations, error) {
+	if a.dormancy == nil {
+		return nil, nil, fmt.Errorf("dormant user detection is disabled, set dormant-days to enable it")
+	}
+
+	annos := annotations.Annotations{}
+	var dormantIDs []string
+	pageToken := ""
+	for {
+		users, nextPageToken, userAnnos, err := a.client.GetUsers(ctx, client.PageOptions{
+			PageSize:  client.DefaultPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, annos, fmt.Errorf("failed to list users: %w", err)
+		}
+		annos = append(annos, userAnnos...)
+
+		for _, user := range users {
+			if user.UserStatus == "Closed" {
+				continue
+			}
+			userCopy := user
+			activity, activityAnnos, err := a.dormancy.Check(ctx, &userCopy)
+			if err != nil {
+				return nil, annos, err
+			}
+			annos = append(annos, activityAnnos...)
+			if !activity.Dormant {
+				continue
+			}
+			dormantIDs = append(dormantIDs, user.UserId)
+		}
+
+		if nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+
+	var closedIDs []interface{}
+	var failures []interface{}
+	if !dryRun {
+		for start := 0; start < len(dormantIDs); start += client.DefaultPageSize {
+			end := min(start+client.DefaultPageSize, len(dormantIDs))
+			closed, closeAnnos, err := a.client.CloseUsers(ctx, dormantIDs[start:end])
+			if err != nil {
+				return nil, annos, err
+			}
+			annos = append(annos, closeAnnos...)
+
+			for _, user := range closed.Users {
+				if user.ErrorDetails != nil {
+					failures = append(failures, fmt.Sprintf("%s: %s - %s", user.UserId, user.ErrorDetails.ErrorCode, user.ErrorDetails.Message))
+					continue
+				}
+				closedIDs = append(closedIDs, user.UserId)
+			}
+		}
+	}
+
+	response, err := structpb.NewStruct(map[string]interface{}{
+		"dry_run":         dryRun,
+		"dormant_days":    a.dormancy.days,
+		"dormant_count":   len(dormantIDs),
+		"dormant_users":   stringsToInterfaces(dormantIDs),
+		"closed_user_ids": closedIDs,
+		"failures":        failures,
+	})
+	if err != nil {
+		return nil, annos, fmt.Errorf("failed to build action response: %w", err)
+	}
+
+	return response, annos, nil
+}
+
+// closeDormantUsersSchema describes the action that closes dormant users in bulk.
+var closeDormantUsersSchema = &v2.BatonActionSchema{
+	Name:        actionCloseDormantUsers,
+	DisplayName: "Close Dormant Users",
+	Description: "Closes every DocuSign user without login or sending activity within the configured dormant-days window",
+	Arguments: []*config.Field{
+		{
+			Name:        "dry_run",
+			DisplayName: "Dry Run",
+			Description: "Only list the users that would be closed without closing them",
+			Field: &config.Field_BoolField{
+				BoolField: &config.BoolField{DefaultValue: true},
+			},
+		},
+	},
+	ReturnTypes: []*config.Field{
+		{
+			Name:        "dormant_users",
+			DisplayName: "Dormant Users",
+			Description: "IDs of the users found without activity in the dormant-days window",
+			Field:       &config.Field_StringSliceField{StringSliceField: &config.StringSliceField{}},
+		},
+		{
+			Name:        "closed_user_ids",
+			DisplayName: "Closed User IDs",
+			Description: "IDs of the users that were closed",
+			Field:       &config.Field_StringSliceField{StringSliceField: &config.StringSliceField{}},
+		},
+		{
+			Name:        "failures",
+			DisplayName: "Failures",
+			Description: "Users that could not be closed, as \"user ID: error code - message\"",
+			Field:       &config.Field_StringSliceField{StringSliceField: &config.StringSliceField{}},
+		},
+	},
+}
+
+// stringsToInterfaces converts a string slice into the list type structpb accepts.
+func stringsToInterfaces(values []string) []interface{} {
+	list := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		list = append(list, value)
+	}
+	return list
+}
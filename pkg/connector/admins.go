@@ -0,0 +1,62 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+)
+
+// AdminClient defines the methods required to count the account administrators.
+type AdminClient interface {
+	GetUsers(ctx context.Context, options client.PageOptions) ([]client.User, string, annotations.Annotations, error)
+}
+
+// isUserAdmin reports whether DocuSign flags the user as an account administrator.
+func isUserAdmin(user *client.UserDetail) bool {
+	return strings.EqualFold(user.IsAdmin, "true")
+}
+
+// isAdminPermissionProfile reports whether users assigned the profile are account administrators.
+func isAdminPermissionProfile(profile *client.PermissionProfile) bool {
+	value, ok := profile.Settings["canManageAccount"].(string)
+	return ok && strings.EqualFold(value, "true")
+}
+
+// ensureNotLastAdmin returns an error when userID is the only active administrator of the account,
+// so that removing its administrator rights would lock everyone out of the account settings.
+func ensureNotLastAdmin(ctx context.Context, adminClient AdminClient, userID string) (annotations.Annotations, error) {
+	annos := annotations.Annotations{}
+	otherAdmins := 0
+
+	pageToken := ""
+	for {
+		users, nextPageToken, pageAnnos, err := adminClient.GetUsers(ctx, client.PageOptions{
+			PageSize:  client.DefaultPageSize,
+			PageToken: pageToken,
+		})
+		annos = append(annos, pageAnnos...)
+		if err != nil {
+			return annos, fmt.Errorf("docusign-connector: failed to list users: %w", err)
+		}
+
+		for _, user := range users {
+			if user.UserId != userID && strings.EqualFold(user.IsAdmin, "true") && strings.EqualFold(user.UserStatus, "active") {
+				otherAdmins++
+			}
+		}
+
+		if otherAdmins > 0 || nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+
+	if otherAdmins == 0 {
+		return annos, fmt.Errorf("docusign-connector: refusing to remove administrator rights from %s, the last account administrator", userID)
+	}
+
+	return annos, nil
+}
@@ -0,0 +1,214 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	"github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+// Entitlement value representing a group's permission to send with a brand.
+const (
+	entitlementBrandUsableBy = "usable_by"
+)
+
+// BrandClient defines the methods required for brand-related API calls.
+type BrandClient interface {
+	GetBrands(ctx context.Context) (*client.BrandsResponse, annotations.Annotations, error)
+	GetGroupBrands(ctx context.Context, groupID string) ([]client.Brand, annotations.Annotations, error)
+	AddGroupBrands(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error)
+	RemoveGroupBrands(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error)
+}
+
+// brandBuilder syncs the account brands and provisions which groups can send with them.
+// Grants are emitted by the groupBuilder, as DocuSign only exposes brand assignments per group.
+type brandBuilder struct {
+	resourceType *v2.ResourceType
+	client       BrandClient
+}
+
+// ResourceType returns the Baton resource type handled by this builder.
+func (b *brandBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return brandResourceType
+}
+
+// List fetches the account brands and converts them to Baton resources.
+func (b *brandBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	response, annos, err := b.client.GetBrands(ctx)
+	if err != nil {
+		return nil, "", annos, fmt.Errorf("docusign-connector: failed to list brands: %w", err)
+	}
+
+	resources := make([]*v2.Resource, 0, len(response.Brands))
+	for _, brand := range response.Brands {
+		brandResource, err := parseIntoBrandResource(brand, response)
+		if err != nil {
+			return nil, "", annos, err
+		}
+		resources = append(resources, brandResource)
+	}
+
+	return resources, "", annos, nil
+}
+
+// Entitlements returns a "usable_by" entitlement for each brand, grantable to groups.
+func (b *brandBuilder) Entitlements(ctx context.Context, brandResource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	ent := entitlement.NewAssignmentEntitlement(
+		brandResource,
+		entitlementBrandUsableBy,
+		entitlement.WithGrantableTo(groupResourceType),
+		entitlement.WithDisplayName(fmt.Sprintf("Can send with %s", brandResource.DisplayName)),
+		entitlement.WithDescription(fmt.Sprintf("Members of the group can send envelopes with the %s brand", brandResource.DisplayName)),
+	)
+	return []*v2.Entitlement{ent}, "", annotations.Annotations{}, nil
+}
+
+// Grants returns nothing as brand assignments are emitted by the groupBuilder.
+func (b *brandBuilder) Grants(ctx context.Context, brandResource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+// Grant assigns the brand to the group. Groups that already have the brand are reported as such.
+func (b *brandBuilder) Grant(ctx context.Context, principal *v2.Resource, ent *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	if principal.Id.ResourceType != groupResourceType.Id {
+		return nil, nil, fmt.Errorf("docusign-connector: brands can only be assigned to groups")
+	}
+
+	groupID := principal.Id.Resource
+	brandID := ent.Resource.Id.Resource
+
+	hasBrand, annos, err := b.groupHasBrand(ctx, groupID, brandID)
+	if err != nil {
+		return nil, annos, err
+	}
+	if hasBrand {
+		annos.Update(&v2.GrantAlreadyExists{})
+		return nil, annos, nil
+	}
+
+	addAnnos, err := b.client.AddGroupBrands(ctx, groupID, []string{brandID})
+	annos = append(annos, addAnnos...)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to assign brand %s to group %s: %w", brandID, groupID, err)
+	}
+
+	return []*v2.Grant{newGroupBrandGrant(ent.Resource, principal.Id, principal.DisplayName)}, annos, nil
+}
+
+// Revoke unassigns the brand from the group. Groups without the brand are reported as already revoked.
+func (b *brandBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	if g.Principal.Id.ResourceType != groupResourceType.Id {
+		return nil, fmt.Errorf("docusign-connector: brands can only be unassigned from groups")
+	}
+
+	groupID := g.Principal.Id.Resource
+	brandID := g.Entitlement.Resource.Id.Resource
+
+	hasBrand, annos, err := b.groupHasBrand(ctx, groupID, brandID)
+	if err != nil {
+		return annos, err
+	}
+	if !hasBrand {
+		annos.Update(&v2.GrantAlreadyRevoked{})
+		return annos, nil
+	}
+
+	removeAnnos, err := b.client.RemoveGroupBrands(ctx, groupID, []string{brandID})
+	annos = append(annos, removeAnnos...)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to unassign brand %s from group %s: %w", brandID, groupID, err)
+	}
+
+	return annos, nil
+}
+
+// groupHasBrand reports whether the brand is assigned to the group.
+func (b *brandBuilder) groupHasBrand(ctx context.Context, groupID, brandID string) (bool, annotations.Annotations, error) {
+	brands, annos, err := b.client.GetGroupBrands(ctx, groupID)
+	if err != nil {
+		return false, annos, fmt.Errorf("docusign-connector: failed to get brands for group %s: %w", groupID, err)
+	}
+	for _, brand := range brands {
+		if brand.BrandId == brandID {
+			return true, annos, nil
+		}
+	}
+	return false, annos, nil
+}
+
+// newBrandBuilder constructs a brandBuilder with the provided API client.
+func newBrandBuilder(client BrandClient) *brandBuilder {
+	return &brandBuilder{
+		resourceType: brandResourceType,
+		client:       client,
+	}
+}
+
+// parseIntoBrandResource converts a DocuSign brand into a Baton resource.
+// The profile records whether the brand is a default, for the brand itself or for the account.
+func parseIntoBrandResource(brand client.Brand, account *client.BrandsResponse) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"brand_id":                  brand.BrandId,
+		"brand_company":             brand.BrandCompany,
+		"is_sending_default":        strings.EqualFold(brand.IsSendingDefault, "true"),
+		"is_signing_default":        strings.EqualFold(brand.IsSigningDefault, "true"),
+		"account_sender_default":    brand.BrandId == account.SenderBrandIdDefault,
+		"account_recipient_default": brand.BrandId == account.RecipientBrandIdDefault,
+		"default_brand_language":    brand.DefaultBrandLanguage,
+		"brand_languages":           strings.Join(brand.BrandLanguages, ","),
+	}
+
+	displayName := brand.BrandName
+	if displayName == "" {
+		displayName = brand.BrandId
+	}
+
+	brandResource, err := resource.NewRoleResource(
+		displayName,
+		brandResourceType,
+		brand.BrandId,
+		[]resource.RoleTraitOption{resource.WithRoleProfile(profile)},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create brand resource: %w", err)
+	}
+	return brandResource, nil
+}
+
+// createGroupBrandGrants generates a "usable_by" grant for every brand assigned to the group.
+func createGroupBrandGrants(groupResource *v2.Resource, brands []client.Brand) []*v2.Grant {
+	grants := make([]*v2.Grant, 0, len(brands))
+	for _, brand := range brands {
+		brandResource := &v2.Resource{
+			Id: &v2.ResourceId{
+				ResourceType: brandResourceType.Id,
+				Resource:     brand.BrandId,
+			},
+			DisplayName: brand.BrandName,
+		}
+		grants = append(grants, newGroupBrandGrant(brandResource, groupResource.Id, groupResource.DisplayName))
+	}
+	return grants
+}
+
+// newGroupBrandGrant builds the grant of a brand's "usable_by" entitlement to a group.
+func newGroupBrandGrant(brandResource *v2.Resource, groupID *v2.ResourceId, groupName string) *v2.Grant {
+	return grant.NewGrant(
+		brandResource,
+		entitlementBrandUsableBy,
+		groupID,
+		grant.WithGrantMetadata(map[string]interface{}{
+			"brand_id":   brandResource.Id.Resource,
+			"brand_name": brandResource.DisplayName,
+			"group_id":   groupID.Resource,
+			"group_name": groupName,
+		}),
+	)
+}
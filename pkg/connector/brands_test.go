@@ -0,0 +1,147 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	"github.com/conductorone/baton-docusign/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockGroupBrandsClient keeps the brands of each group in memory and records updates.
+func mockGroupBrandsClient(groupBrands map[string][]string, added, removed *[]string) *test.MockClient {
+	return &test.MockClient{
+		GetGroupBrandsFunc: func(ctx context.Context, groupID string) ([]client.Brand, annotations.Annotations, error) {
+			var brands []client.Brand
+			for _, brandID := range groupBrands[groupID] {
+				brands = append(brands, client.Brand{BrandId: brandID, BrandName: "Brand " + brandID})
+			}
+			return brands, nil, nil
+		},
+		AddGroupBrandsFunc: func(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error) {
+			*added = append(*added, brandIDs...)
+			return nil, nil
+		},
+		RemoveGroupBrandsFunc: func(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error) {
+			*removed = append(*removed, brandIDs...)
+			return nil, nil
+		},
+	}
+}
+
+// TestBrandBuilder_List verifies brands are listed with their default status and a "usable_by" entitlement.
+func TestBrandBuilder_List(t *testing.T) {
+	var response client.BrandsResponse
+	require.NoError(t, json.Unmarshal([]byte(test.ReadFile("brands.json")), &response))
+	builder := newBrandBuilder(&test.MockClient{
+		GetBrandsFunc: func(ctx context.Context) (*client.BrandsResponse, annotations.Annotations, error) {
+			return &response, nil, nil
+		},
+	})
+
+	resources, nextToken, _, err := builder.List(context.Background(), nil, &pagination.Token{})
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Empty(t, nextToken)
+	assert.Equal(t, "Acme", resources[0].DisplayName)
+
+	roleTrait, err := resource.GetRoleTrait(resources[0])
+	require.NoError(t, err)
+	profile := roleTrait.GetProfile().AsMap()
+	assert.Equal(t, true, profile["is_sending_default"])
+	assert.Equal(t, true, profile["account_sender_default"])
+	assert.Equal(t, false, profile["account_recipient_default"])
+	assert.Equal(t, "en,fr", profile["brand_languages"])
+
+	ents, _, _, err := builder.Entitlements(context.Background(), resources[0], nil)
+	require.NoError(t, err)
+	require.Len(t, ents, 1)
+	assert.Equal(t, entitlementBrandUsableBy, ents[0].Slug)
+}
+
+// TestBrandBuilder_Grant verifies brands are assigned to groups only once, and never to users.
+func TestBrandBuilder_Grant(t *testing.T) {
+	var added, removed []string
+	builder := newBrandBuilder(mockGroupBrandsClient(map[string][]string{"g1": {"b1"}}, &added, &removed))
+	groupRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: groupResourceType.Id, Resource: "g1"}, DisplayName: "Developers"}
+
+	ent := &v2.Entitlement{Resource: &v2.Resource{Id: &v2.ResourceId{ResourceType: brandResourceType.Id, Resource: "b2"}, DisplayName: "Acme Legal"}}
+	grants, _, err := builder.Grant(context.Background(), groupRes, ent)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, "g1", grants[0].Principal.Id.Resource)
+	assert.Equal(t, []string{"b2"}, added)
+
+	ent.Resource.Id.Resource = "b1"
+	grants, annos, err := builder.Grant(context.Background(), groupRes, ent)
+	require.NoError(t, err)
+	assert.Empty(t, grants)
+	assert.True(t, annos.Contains(&v2.GrantAlreadyExists{}))
+	assert.Equal(t, []string{"b2"}, added)
+
+	userRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}
+	_, _, err = builder.Grant(context.Background(), userRes, ent)
+	require.Error(t, err)
+}
+
+// TestBrandBuilder_Revoke verifies brands are unassigned from groups and revoking is idempotent.
+func TestBrandBuilder_Revoke(t *testing.T) {
+	var added, removed []string
+	builder := newBrandBuilder(mockGroupBrandsClient(map[string][]string{"g1": {"b1"}}, &added, &removed))
+	groupRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: groupResourceType.Id, Resource: "g1"}, DisplayName: "Developers"}
+	brandRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: brandResourceType.Id, Resource: "b1"}, DisplayName: "Acme"}
+
+	g := newGroupBrandGrant(brandRes, groupRes.Id, groupRes.DisplayName)
+	_, err := builder.Revoke(context.Background(), g)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b1"}, removed)
+
+	brandRes.Id.Resource = "b2"
+	annos, err := builder.Revoke(context.Background(), newGroupBrandGrant(brandRes, groupRes.Id, groupRes.DisplayName))
+	require.NoError(t, err)
+	assert.True(t, annos.Contains(&v2.GrantAlreadyRevoked{}))
+	assert.Equal(t, []string{"b1"}, removed)
+}
+
+// TestGroupBuilder_Grants_Brands verifies the first page of a group's grants includes its brand assignments.
+func TestGroupBuilder_Grants_Brands(t *testing.T) {
+	var added, removed []string
+	builder := newTestGroupBuilder(mockGroupBrandsClient(map[string][]string{"g1": {"b1", "b2"}}, &added, &removed))
+	groupRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: groupResourceType.Id, Resource: "g1"}, DisplayName: "Developers"}
+
+	grants, _, _, err := builder.Grants(context.Background(), groupRes, &pagination.Token{})
+	require.NoError(t, err)
+
+	var brandIDs []string
+	for _, g := range grants {
+		if g.Entitlement.Resource.Id.ResourceType == brandResourceType.Id {
+			assert.Equal(t, "g1", g.Principal.Id.Resource)
+			brandIDs = append(brandIDs, g.Entitlement.Resource.Id.Resource)
+		}
+	}
+	assert.ElementsMatch(t, []string{"b1", "b2"}, brandIDs)
+
+	t.Run("skips brands that cannot be read", func(t *testing.T) {
+		builder := newTestGroupBuilder(&test.MockClient{
+			GetGroupBrandsFunc: func(ctx context.Context, groupID string) ([]client.Brand, annotations.Annotations, error) {
+				return nil, nil, fmt.Errorf("brands are not enabled for this account")
+			},
+			GetGroupUsersFunc: func(ctx context.Context, groupID string, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+				return []client.User{{UserId: "u1"}}, "", nil, nil
+			},
+		})
+
+		grants, _, _, err := builder.Grants(context.Background(), groupRes, &pagination.Token{})
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, "u1", grants[0].Principal.Id.Resource)
+	})
+}
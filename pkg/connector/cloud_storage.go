@@ -0,0 +1,183 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	"github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+// Entitlement value representing a user's connection to a cloud storage provider.
+const (
+	entitlementCloudStorageConnected = "connected"
+)
+
+// cloudStorageProvider describes a cloud storage service users can connect to DocuSign.
+type cloudStorageProvider struct {
+	ID          string
+	DisplayName string
+}
+
+// cloudStorageProviders contains the cloud storage services DocuSign supports.
+var cloudStorageProviders = []cloudStorageProvider{
+	{"box", "Box"},
+	{"dropbox", "Dropbox"},
+	{"googledrive", "Google Drive"},
+	{"onedrive", "OneDrive"},
+	{"egnyte", "Egnyte"},
+	{"evernote", "Evernote"},
+	{"salesforce", "Salesforce"},
+}
+
+// CloudStorageClient defines the methods required for cloud storage API calls.
+type CloudStorageClient interface {
+	GetUserCloudStorage(ctx context.Context, userID string) ([]client.CloudStorageProvider, annotations.Annotations, error)
+	DeleteUserCloudStorage(ctx context.Context, userID, serviceID string) (annotations.Annotations, error)
+}
+
+// cloudStorageBuilder syncs cloud storage providers and revokes user connections to them.
+// Grants are emitted by the userBuilder, which already fetches data per user.
+type cloudStorageBuilder struct {
+	resourceType *v2.ResourceType
+	client       CloudStorageClient
+}
+
+// ResourceType returns the Baton resource type handled by this builder.
+func (c *cloudStorageBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return cloudStorageProviderResourceType
+}
+
+// List returns a resource for every cloud storage provider DocuSign supports.
+func (c *cloudStorageBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	resources := make([]*v2.Resource, 0, len(cloudStorageProviders))
+	for _, provider := range cloudStorageProviders {
+		providerResource, err := resource.NewResource(provider.DisplayName, cloudStorageProviderResourceType, provider.ID)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to create cloud storage provider resource: %w", err)
+		}
+		resources = append(resources, providerResource)
+	}
+
+	return resources, "", annotations.Annotations{}, nil
+}
+
+// Entitlements returns a "connected" entitlement for each provider, grantable to users.
+func (c *cloudStorageBuilder) Entitlements(ctx context.Context, providerResource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	ent := entitlement.NewAssignmentEntitlement(
+		providerResource,
+		entitlementCloudStorageConnected,
+		entitlement.WithGrantableTo(userResourceType),
+		entitlement.WithDisplayName(fmt.Sprintf("Connected to %s", providerResource.DisplayName)),
+		entitlement.WithDescription(fmt.Sprintf("Has connected a %s account to DocuSign", providerResource.DisplayName)),
+	)
+	return []*v2.Entitlement{ent}, "", annotations.Annotations{}, nil
+}
+
+// Grants returns nothing as connections are emitted by the userBuilder.
+func (c *cloudStorageBuilder) Grants(ctx context.Context, providerResource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+// Grant is not supported because connecting a cloud storage account requires the user to authorize it in DocuSign.
+func (c *cloudStorageBuilder) Grant(ctx context.Context, principal *v2.Resource, ent *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	return nil, nil, fmt.Errorf("docusign-connector: connecting %s requires the user to authorize it in DocuSign", ent.Resource.DisplayName)
+}
+
+// Revoke deletes the user's connection to the cloud storage provider.
+func (c *cloudStorageBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	if g.Principal.Id.ResourceType != userResourceType.Id {
+		return nil, fmt.Errorf("docusign-connector: only users can be disconnected from a cloud storage provider")
+	}
+
+	userID := g.Principal.Id.Resource
+	providerID := g.Entitlement.Resource.Id.Resource
+
+	providers, annos, err := c.client.GetUserCloudStorage(ctx, userID)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to get cloud storage for %s: %w", userID, err)
+	}
+
+	for _, provider := range providers {
+		if !isCloudStorageConnected(provider) || cloudStorageProviderID(provider.Service) != providerID {
+			continue
+		}
+		deleteAnnos, err := c.client.DeleteUserCloudStorage(ctx, userID, provider.ServiceId)
+		annos = append(annos, deleteAnnos...)
+		if err != nil {
+			return annos, fmt.Errorf("docusign-connector: failed to disconnect %s for %s: %w", providerID, userID, err)
+		}
+		return annos, nil
+	}
+
+	annos.Update(&v2.GrantAlreadyRevoked{})
+	return annos, nil
+}
+
+// newCloudStorageBuilder constructs a cloudStorageBuilder with the provided API client.
+func newCloudStorageBuilder(client CloudStorageClient) *cloudStorageBuilder {
+	return &cloudStorageBuilder{
+		resourceType: cloudStorageProviderResourceType,
+		client:       client,
+	}
+}
+
+// createCloudStorageGrants generates a "connected" grant for every provider the user has linked.
+func createCloudStorageGrants(user *client.UserDetail, providers []client.CloudStorageProvider) []*v2.Grant {
+	var grants []*v2.Grant
+	for _, provider := range providers {
+		if !isCloudStorageConnected(provider) {
+			continue
+		}
+		providerID := cloudStorageProviderID(provider.Service)
+		if !isKnownCloudStorageProvider(providerID) {
+			continue
+		}
+
+		providerResource := &v2.Resource{
+			Id: &v2.ResourceId{
+				ResourceType: cloudStorageProviderResourceType.Id,
+				Resource:     providerID,
+			},
+		}
+		grants = append(grants, grant.NewGrant(
+			providerResource,
+			entitlementCloudStorageConnected,
+			makeUserSubjectID(user.UserID),
+			grant.WithGrantMetadata(map[string]interface{}{
+				"provider":   provider.Service,
+				"service_id": provider.ServiceId,
+				"user_id":    user.UserID,
+				"username":   user.UserName,
+			}),
+		))
+	}
+	return grants
+}
+
+// isCloudStorageConnected reports whether the user has completed authorization with the provider.
+// DocuSign returns an authentication URL for providers that are enabled but not yet connected.
+func isCloudStorageConnected(provider client.CloudStorageProvider) bool {
+	return provider.ErrorDetails == nil && provider.AuthenticationUrl == ""
+}
+
+// cloudStorageProviderID normalizes a DocuSign service name into a provider resource ID.
+func cloudStorageProviderID(service string) string {
+	return strings.ToLower(strings.ReplaceAll(service, " ", ""))
+}
+
+// isKnownCloudStorageProvider reports whether the provider is synced as a resource.
+func isKnownCloudStorageProvider(providerID string) bool {
+	for _, provider := range cloudStorageProviders {
+		if provider.ID == providerID {
+			return true
+		}
+	}
+	return false
+}
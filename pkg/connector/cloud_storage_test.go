@@ -0,0 +1,130 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCloudStorageClient implements CloudStorageClient and records deleted connections.
+type mockCloudStorageClient struct {
+	providers []client.CloudStorageProvider
+	deleted   []string
+}
+
+func (m *mockCloudStorageClient) GetUserCloudStorage(ctx context.Context, userID string) ([]client.CloudStorageProvider, annotations.Annotations, error) {
+	return m.providers, nil, nil
+}
+
+func (m *mockCloudStorageClient) DeleteUserCloudStorage(ctx context.Context, userID, serviceID string) (annotations.Annotations, error) {
+	m.deleted = append(m.deleted, serviceID)
+	return nil, nil
+}
+
+var testCloudStorageProviders = []client.CloudStorageProvider{
+	{Service: "Box", ServiceId: "136"},
+	{Service: "Dropbox", ServiceId: "137", AuthenticationUrl: "https://www.dropbox.com/oauth2/authorize"},
+	{Service: "Google Drive", ServiceId: "138"},
+}
+
+// TestCloudStorageBuilder_List verifies every supported provider is listed with a "connected" entitlement.
+func TestCloudStorageBuilder_List(t *testing.T) {
+	builder := newCloudStorageBuilder(&mockCloudStorageClient{})
+
+	resources, nextToken, _, err := builder.List(context.Background(), nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, resources, len(cloudStorageProviders))
+	assert.Empty(t, nextToken)
+
+	ents, _, _, err := builder.Entitlements(context.Background(), resources[0], nil)
+	require.NoError(t, err)
+	require.Len(t, ents, 1)
+	assert.Equal(t, entitlementCloudStorageConnected, ents[0].Slug)
+}
+
+// TestUserBuilder_Grants_CloudStorage verifies users get grants only for providers they have connected.
+func TestUserBuilder_Grants_CloudStorage(t *testing.T) {
+	mockClient := &mockClient{
+		getUserDetailsFunc: func(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+			return &client.UserDetail{UserID: userID, UserName: "alice"}, nil, nil
+		},
+		getUserCloudStorageFunc: func(ctx context.Context, userID string) ([]client.CloudStorageProvider, annotations.Annotations, error) {
+			return testCloudStorageProviders, nil, nil
+		},
+	}
+	builder := &userBuilder{resourceType: userResourceType, client: mockClient, permissionBuilder: newPermissionBuilder(nil, nil, false)}
+
+	userRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}
+	grants, _, _, err := builder.Grants(context.Background(), userRes, nil)
+	require.NoError(t, err)
+
+	var providerIDs []string
+	for _, g := range grants {
+		if g.Entitlement.Resource.Id.ResourceType == cloudStorageProviderResourceType.Id {
+			providerIDs = append(providerIDs, g.Entitlement.Resource.Id.Resource)
+		}
+	}
+	assert.ElementsMatch(t, []string{"box", "googledrive"}, providerIDs)
+}
+
+// TestUserBuilder_Grants_CloudStorageError verifies a failed cloud storage lookup skips only the cloud storage grants.
+func TestUserBuilder_Grants_CloudStorageError(t *testing.T) {
+	mockClient := &mockClient{
+		getUserDetailsFunc: func(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+			return &client.UserDetail{UserID: userID, UserName: "alice", PermissionProfileId: "1002"}, nil, nil
+		},
+		getUserCloudStorageFunc: func(ctx context.Context, userID string) ([]client.CloudStorageProvider, annotations.Annotations, error) {
+			return nil, nil, errors.New("cloud storage is not enabled for this account")
+		},
+	}
+	builder := &userBuilder{resourceType: userResourceType, client: mockClient, permissionBuilder: newPermissionBuilder(nil, nil, false)}
+
+	userRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}
+	grants, _, _, err := builder.Grants(context.Background(), userRes, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, grants)
+	for _, g := range grants {
+		assert.NotEqual(t, cloudStorageProviderResourceType.Id, g.Entitlement.Resource.Id.ResourceType)
+	}
+}
+
+// TestCloudStorageBuilder_Revoke verifies revoking deletes the matching connection, is idempotent, and only applies to users.
+func TestCloudStorageBuilder_Revoke(t *testing.T) {
+	storageClient := &mockCloudStorageClient{providers: testCloudStorageProviders}
+	builder := newCloudStorageBuilder(storageClient)
+
+	userDetail := &client.UserDetail{UserID: "u1", UserName: "alice"}
+	grants := createCloudStorageGrants(userDetail, testCloudStorageProviders)
+	require.Len(t, grants, 2)
+
+	annos, err := builder.Revoke(context.Background(), grants[0])
+	require.NoError(t, err)
+	assert.False(t, annos.Contains(&v2.GrantAlreadyRevoked{}))
+	assert.Equal(t, []string{"136"}, storageClient.deleted)
+
+	dropboxGrant := grant.NewGrant(
+		&v2.Resource{Id: &v2.ResourceId{ResourceType: cloudStorageProviderResourceType.Id, Resource: "dropbox"}},
+		entitlementCloudStorageConnected,
+		makeUserSubjectID("u1"),
+	)
+	annos, err = builder.Revoke(context.Background(), dropboxGrant)
+	require.NoError(t, err)
+	assert.True(t, annos.Contains(&v2.GrantAlreadyRevoked{}))
+	assert.Equal(t, []string{"136"}, storageClient.deleted)
+
+	groupGrant := grant.NewGrant(
+		grants[1].Entitlement.Resource,
+		entitlementCloudStorageConnected,
+		&v2.ResourceId{ResourceType: groupResourceType.Id, Resource: "u1"},
+	)
+	_, err = builder.Revoke(context.Background(), groupGrant)
+	require.Error(t, err)
+	assert.Equal(t, []string{"136"}, storageClient.deleted)
+}
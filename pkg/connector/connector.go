@@ -2,6 +2,7 @@ package connector
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"github.com/conductorone/baton-docusign/pkg/client"
@@ -12,16 +13,72 @@ import (
 	"go.uber.org/zap"
 )
 
+// Config holds the settings used to build the DocuSign connector.
+type Config struct {
+	ApiUrl         string
+	AccountId      string
+	ClientId       string
+	ClientSecret   string
+	RedirectURI    string
+	RefreshToken   string
+	AdminApiUrl    string
+	OrganizationId string
+	// DormantDays enables dormant user detection when greater than zero.
+	DormantDays int
+	// EnvelopeUsageDays enables per-user envelope usage statistics over this many days when greater than zero.
+	EnvelopeUsageDays int
+	// InternalDomains lists email domains treated as internal in addition to the organization's verified domains.
+	InternalDomains []string
+	// FallbackPermissionProfileId is the permission profile users are moved to when a profile assignment is revoked.
+	FallbackPermissionProfileId string
+	// PermissionCatalogFile is a JSON file extending or overriding the built-in permission catalog.
+	PermissionCatalogFile string
+	// SkipEveryoneGroupGrants drops the member grants of the Everyone group, which every user holds.
+	SkipEveryoneGroupGrants bool
+	// PermissionResourceMode is "singleton" (the default) or "per-permission", which syncs each permission as its own resource.
+	PermissionResourceMode string
+	// GroupMembershipStrategy is "group-centric" (the default), which pages through the users of each group,
+	// or "user-centric", which builds group memberships from each user's group list.
+	GroupMembershipStrategy string
+	// ReactivateClosedUsers reactivates closed users before granting them a group or permission profile.
+	ReactivateClosedUsers bool
+}
+
 type Connector struct {
-	client *client.Client
+	client        *client.Client
+	config        Config
+	dormancy      *dormancyChecker
+	envelopeUsage *envelopeUsageCounter
+	domains       *domainClassifier
+	catalog       *permissionCatalog
+	actions       *actionManager
 }
 
 func (d *Connector) ResourceSyncers(ctx context.Context) []connectorbuilder.ResourceSyncer {
-	pb := newPermissionBuilder(d.client)
+	pb := newPermissionBuilder(d.client, d.catalog, d.config.PermissionResourceMode == permissionModePerPermission)
+	gb := newGroupBuilder(d.client, d.config.SkipEveryoneGroupGrants, d.config.GroupMembershipStrategy == membershipStrategyUserCentric)
+	ppb := newPermissionProfileBuilder(d.client, d.config.FallbackPermissionProfileId, pb)
+	if d.config.ReactivateClosedUsers {
+		gb.withClosedUserReactivation()
+		ppb.withClosedUserReactivation()
+	}
+	enrichers := userEnrichers{
+		dormancy:      d.dormancy,
+		envelopeUsage: d.envelopeUsage,
+		domains:       d.domains,
+	}
+	if d.client.HasOrganization() {
+		enrichers.orgClient = d.client
+	}
 	return []connectorbuilder.ResourceSyncer{
-		newUserBuilder(d.client, pb),
-		newGroupBuilder(d.client),
+		newUserBuilder(d.client, pb, gb, enrichers),
+		gb,
 		pb,
+		ppb,
+		newCloudStorageBuilder(d.client),
+		newBrandBuilder(d.client),
+		newSigningGroupBuilder(d.client),
+		newAccountBuilder(d.client, d.config.FallbackPermissionProfileId),
 	}
 }
 
@@ -64,16 +121,67 @@ func (d *Connector) Validate(ctx context.Context) (annotations.Annotations, erro
 	return nil, nil
 }
 
-func New(ctx context.Context, apiUrl, accountId, clientId, clientSecret, redirectURI, refreshToken string) (*Connector, error) {
+func New(ctx context.Context, cfg Config) (*Connector, error) {
 	l := ctxzap.Extract(ctx)
 
-	docusignClient, err := client.New(ctx, apiUrl, accountId, clientId, clientSecret, redirectURI, refreshToken)
+	docusignClient, err := client.New(ctx, cfg.ApiUrl, cfg.AccountId, cfg.ClientId, cfg.ClientSecret, cfg.RedirectURI, cfg.RefreshToken)
 	if err != nil {
 		l.Error("error creating DocuSign client", zap.Error(err))
 		return nil, err
 	}
 
+	if cfg.OrganizationId != "" {
+		docusignClient.WithOrganization(cfg.AdminApiUrl, cfg.OrganizationId)
+	}
+
+	switch cfg.PermissionResourceMode {
+	case "", permissionModeSingleton, permissionModePerPermission:
+	default:
+		err := fmt.Errorf("docusign-connector: unknown permission resource mode %q", cfg.PermissionResourceMode)
+		l.Error("error validating config", zap.Error(err))
+		return nil, err
+	}
+
+	switch cfg.GroupMembershipStrategy {
+	case "", membershipStrategyGroupCentric, membershipStrategyUserCentric:
+	default:
+		err := fmt.Errorf("docusign-connector: unknown group membership strategy %q", cfg.GroupMembershipStrategy)
+		l.Error("error validating config", zap.Error(err))
+		return nil, err
+	}
+
+	catalog, err := loadPermissionCatalog(cfg.PermissionCatalogFile)
+	if err != nil {
+		l.Error("error loading permission catalog", zap.Error(err))
+		return nil, err
+	}
+
+	var dormancy *dormancyChecker
+	if cfg.DormantDays > 0 {
+		dormancy = newDormancyChecker(docusignClient, cfg.DormantDays)
+	}
+
+	var envelopeUsage *envelopeUsageCounter
+	if cfg.EnvelopeUsageDays > 0 {
+		envelopeUsage = newEnvelopeUsageCounter(docusignClient, cfg.EnvelopeUsageDays)
+	}
+
+	// External user detection needs a source of internal domains: the Admin API, configured domains, or both.
+	var domains *domainClassifier
+	switch {
+	case docusignClient.HasOrganization():
+		domains = newDomainClassifier(docusignClient, cfg.InternalDomains)
+	case len(cfg.InternalDomains) > 0:
+		domains = newDomainClassifier(nil, cfg.InternalDomains)
+	}
+
 	return &Connector{
-		client: docusignClient,
+		client:        docusignClient,
+		config:        cfg,
+		dormancy:      dormancy,
+		envelopeUsage: envelopeUsage,
+		domains:       domains,
+		catalog:       catalog,
+		actions:       newActionManager(docusignClient, dormancy),
 	}, nil
 }
@@ -0,0 +1,139 @@
+package connector
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+// reservedDomainStatusActive is the status of a reserved domain the organization has verified.
+const reservedDomainStatusActive = "active"
+
+// DomainClient defines the DocuSign Admin API operations used to load verified domains.
+type DomainClient interface {
+	GetReservedDomains(ctx context.Context) ([]client.ReservedDomain, annotations.Annotations, error)
+}
+
+// domainMatch describes how a user's email domain relates to the organization's domains.
+// Undetermined is set when no internal domain is known, in which case External is not meaningful.
+type domainMatch struct {
+	EmailDomain   string
+	MatchedDomain string
+	External      bool
+	Undetermined  bool
+}
+
+// domainClassifier flags users whose email is outside the organization's verified domains.
+type domainClassifier struct {
+	client     DomainClient
+	configured []string
+
+	mu      sync.Mutex
+	domains []string
+}
+
+// newDomainClassifier creates a domainClassifier from the Admin API client and the configured domains.
+// client is nil when the Admin API isn't available, in which case only the configured domains are used.
+func newDomainClassifier(client DomainClient, configured []string) *domainClassifier {
+	return &domainClassifier{
+		client:     client,
+		configured: configured,
+	}
+}
+
+// Classify returns the domain match for an email address.
+// Without any internal domain, such as when the Admin API fails and none is configured, the match is undetermined
+// rather than external, so the whole account isn't reported as external.
+func (d *domainClassifier) Classify(ctx context.Context, email string) (*domainMatch, annotations.Annotations) {
+	domains, annos := d.getDomains(ctx)
+
+	match := &domainMatch{External: true}
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		match.EmailDomain = strings.ToLower(email[at+1:])
+	}
+	if len(domains) == 0 {
+		match.External = false
+		match.Undetermined = true
+		return match, annos
+	}
+	if match.EmailDomain == "" {
+		return match, annos
+	}
+
+	for _, domain := range domains {
+		if match.EmailDomain == domain || strings.HasSuffix(match.EmailDomain, "."+domain) {
+			match.MatchedDomain = domain
+			match.External = false
+			break
+		}
+	}
+
+	return match, annos
+}
+
+// Profile returns the user profile fields describing the match.
+// An undetermined match has no external flag, and is marked as undetermined instead.
+func (m *domainMatch) Profile() map[string]interface{} {
+	if m.Undetermined {
+		return map[string]interface{}{
+			"email_domain":          m.EmailDomain,
+			"external_undetermined": true,
+		}
+	}
+	return map[string]interface{}{
+		"external":       m.External,
+		"email_domain":   m.EmailDomain,
+		"matched_domain": m.MatchedDomain,
+	}
+}
+
+// reset drops the domains loaded during the previous sync, so the next Classify reloads them.
+func (d *domainClassifier) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.domains = nil
+}
+
+// getDomains returns the verified and configured domains, loading them from the Admin API once per sync.
+// When the Admin API fails, the failure is logged and the configured domains are used for the rest of the sync.
+func (d *domainClassifier) getDomains(ctx context.Context) ([]string, annotations.Annotations) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.domains != nil {
+		return d.domains, nil
+	}
+
+	domains := make([]string, 0, len(d.configured))
+	for _, domain := range d.configured {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	var annos annotations.Annotations
+	if d.client != nil {
+		reserved, reservedAnnos, err := d.client.GetReservedDomains(ctx)
+		annos = reservedAnnos
+		if err != nil {
+			ctxzap.Extract(ctx).Warn("docusign-connector: failed to fetch reserved domains, using the configured internal domains only",
+				zap.Strings("internal_domains", domains),
+				zap.Error(err),
+			)
+		}
+		for _, domain := range reserved {
+			if strings.EqualFold(domain.Status, reservedDomainStatusActive) && domain.HostName != "" {
+				domains = append(domains, strings.ToLower(domain.HostName))
+			}
+		}
+	}
+
+	d.domains = domains
+	return d.domains, annos
+}
@@ -0,0 +1,130 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockDomainClient implements DomainClient and counts how often domains are loaded.
+type mockDomainClient struct {
+	domains []client.ReservedDomain
+	err     error
+	calls   int
+}
+
+func (m *mockDomainClient) GetReservedDomains(ctx context.Context) ([]client.ReservedDomain, annotations.Annotations, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	return m.domains, nil, nil
+}
+
+// TestDomainClassifier_Classify verifies emails are matched against verified and configured domains.
+func TestDomainClassifier_Classify(t *testing.T) {
+	domainClient := &mockDomainClient{
+		domains: []client.ReservedDomain{
+			{ID: "1", Status: "active", HostName: "example.com"},
+			{ID: "2", Status: "pending", HostName: "pending.com"},
+		},
+	}
+	classifier := newDomainClassifier(domainClient, []string{" Partner.org "})
+
+	tests := []struct {
+		email         string
+		wantExternal  bool
+		wantMatched   string
+		wantEmailPart string
+	}{
+		{"alice@example.com", false, "example.com", "example.com"},
+		{"bob@eu.Example.com", false, "example.com", "eu.example.com"},
+		{"carol@partner.org", false, "partner.org", "partner.org"},
+		{"dave@pending.com", true, "", "pending.com"},
+		{"eve@gmail.com", true, "", "gmail.com"},
+		{"not-an-email", true, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			match, _ := classifier.Classify(context.Background(), tt.email)
+			assert.Equal(t, tt.wantExternal, match.External)
+			assert.Equal(t, tt.wantMatched, match.MatchedDomain)
+			assert.Equal(t, tt.wantEmailPart, match.EmailDomain)
+		})
+	}
+
+	assert.Equal(t, 1, domainClient.calls, "reserved domains should only be loaded once")
+
+	classifier.reset()
+	_, _ = classifier.Classify(context.Background(), "alice@example.com")
+	assert.Equal(t, 2, domainClient.calls, "reserved domains should be reloaded after a reset")
+}
+
+// TestDomainClassifier_Classify_AdminAPIUnavailable verifies the configured domains are used when the Admin API fails.
+func TestDomainClassifier_Classify_AdminAPIUnavailable(t *testing.T) {
+	domainClient := &mockDomainClient{err: errors.New("403 Forbidden")}
+	classifier := newDomainClassifier(domainClient, []string{"example.com"})
+
+	match, _ := classifier.Classify(context.Background(), "alice@example.com")
+	assert.False(t, match.External)
+	assert.Equal(t, "example.com", match.MatchedDomain)
+
+	match, _ = classifier.Classify(context.Background(), "eve@gmail.com")
+	assert.True(t, match.External)
+
+	assert.Equal(t, 1, domainClient.calls, "the fallback should be cached for the rest of the sync")
+
+	t.Run("leaves users undetermined without any internal domain", func(t *testing.T) {
+		classifier := newDomainClassifier(&mockDomainClient{err: errors.New("403 Forbidden")}, nil)
+
+		match, _ := classifier.Classify(context.Background(), "alice@example.com")
+		assert.True(t, match.Undetermined)
+		assert.False(t, match.External)
+
+		profile := match.Profile()
+		assert.NotContains(t, profile, "external")
+		assert.Equal(t, true, profile["external_undetermined"])
+		assert.Equal(t, "example.com", profile["email_domain"])
+	})
+}
+
+// TestUserBuilder_Grants_ExternalMetadata verifies permission grants carry the external flag.
+func TestUserBuilder_Grants_ExternalMetadata(t *testing.T) {
+	mockClient := &mockClient{
+		getUserDetailsFunc: func(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+			return &client.UserDetail{
+				UserID: userID,
+				Email:  "contractor@gmail.com",
+				UserSettings: client.UserSettings{
+					CanSendEnvelope: "true",
+				},
+			}, nil, nil
+		},
+	}
+
+	builder := &userBuilder{
+		resourceType:      userResourceType,
+		client:            mockClient,
+		permissionBuilder: newPermissionBuilder(nil, nil, false),
+		userEnrichers:     userEnrichers{domains: newDomainClassifier(nil, []string{"example.com"})},
+	}
+
+	userRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "contractor"}}
+	grants, _, _, err := builder.Grants(context.Background(), userRes, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, grants)
+
+	annos := annotations.Annotations(grants[0].Annotations)
+	metadata := &v2.GrantMetadata{}
+	ok, err := annos.Pick(metadata)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, true, metadata.Metadata.AsMap()["external"])
+}
@@ -0,0 +1,107 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+// ActivityClient defines the DocuSign API operations used to detect dormant users.
+type ActivityClient interface {
+	GetLastSentEnvelopeDate(ctx context.Context, userID string, since time.Time) (*time.Time, annotations.Annotations, error)
+}
+
+// userActivity describes the most recent activity found for a user.
+// Undetermined is set when the user's dates can't be read, in which case the user is never reported dormant.
+type userActivity struct {
+	LastActivity *time.Time
+	Dormant      bool
+	Undetermined bool
+}
+
+// dormancyChecker decides whether users are dormant based on their last login and envelope activity.
+type dormancyChecker struct {
+	client ActivityClient
+	days   int
+	now    func() time.Time
+}
+
+// newDormancyChecker creates a dormancyChecker that flags users without activity in the last days.
+func newDormancyChecker(client ActivityClient, days int) *dormancyChecker {
+	return &dormancyChecker{
+		client: client,
+		days:   days,
+		now:    time.Now,
+	}
+}
+
+// Check computes the last activity of a user and whether it falls outside the dormancy window.
+// Login history comes from the user's last login, falling back to the creation date for users
+// that never logged in. Envelope activity is only looked up when the login alone would mark the
+// user dormant, which keeps the cost to one extra API call per inactive user.
+// Users whose dates can't be parsed are logged and reported as undetermined rather than failing the sync.
+func (d *dormancyChecker) Check(ctx context.Context, user *client.User) (*userActivity, annotations.Annotations, error) {
+	cutoff := d.now().AddDate(0, 0, -d.days)
+
+	lastActivity, err := client.ParseTime(user.LastLogin)
+	if err == nil && lastActivity == nil {
+		lastActivity, err = client.ParseTime(user.CreatedDateTime)
+	}
+	if err != nil {
+		ctxzap.Extract(ctx).Warn("docusign-connector: unable to determine whether user is dormant",
+			zap.String("user_id", user.UserId),
+			zap.Error(err),
+		)
+		return &userActivity{Undetermined: true}, nil, nil
+	}
+
+	if lastActivity == nil || lastActivity.Before(cutoff) {
+		lastSent, annos, err := d.client.GetLastSentEnvelopeDate(ctx, user.UserId, cutoff)
+		if err != nil {
+			return nil, annos, fmt.Errorf("failed to fetch envelope activity for %s: %w", user.UserId, err)
+		}
+		if lastSent != nil && (lastActivity == nil || lastSent.After(*lastActivity)) {
+			lastActivity = lastSent
+		}
+		return &userActivity{
+			LastActivity: lastActivity,
+			Dormant:      lastActivity == nil || lastActivity.Before(cutoff),
+		}, annos, nil
+	}
+
+	return &userActivity{
+		LastActivity: lastActivity,
+		Dormant:      false,
+	}, nil, nil
+}
+
+// Profile returns the user profile fields describing the activity.
+func (a *userActivity) Profile() map[string]interface{} {
+	profile := map[string]interface{}{
+		"dormant":       a.Dormant,
+		"last_activity": "",
+	}
+	if a.Undetermined {
+		profile["dormancy_undetermined"] = true
+	}
+	if a.LastActivity != nil {
+		profile["last_activity"] = a.LastActivity.UTC().Format(time.RFC3339)
+	}
+	return profile
+}
+
+// StatusDetails returns a short description of the activity for the user status details.
+func (a *userActivity) StatusDetails(days int) string {
+	if !a.Dormant {
+		return ""
+	}
+	if a.LastActivity == nil {
+		return fmt.Sprintf("dormant: no activity recorded in the last %d days", days)
+	}
+	return fmt.Sprintf("dormant: no activity in the last %d days, last activity %s", days, a.LastActivity.UTC().Format(time.RFC3339))
+}
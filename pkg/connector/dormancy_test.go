@@ -0,0 +1,174 @@
+package connector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var dormancyTestNow = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// mockActivityClient implements ActivityClient and ActionClient for dormancy tests.
+type mockActivityClient struct {
+	users       []client.User
+	lastSent    map[string]time.Time
+	closedUsers []string
+}
+
+func (m *mockActivityClient) GetLastSentEnvelopeDate(ctx context.Context, userID string, since time.Time) (*time.Time, annotations.Annotations, error) {
+	sent, ok := m.lastSent[userID]
+	if !ok || sent.Before(since) {
+		return nil, nil, nil
+	}
+	return &sent, nil, nil
+}
+
+func (m *mockActivityClient) GetUsers(ctx context.Context, options client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+	return m.users, "", nil, nil
+}
+
+func (m *mockActivityClient) CloseUsers(ctx context.Context, userIDs []string) (*client.UsersCloseResponse, annotations.Annotations, error) {
+	m.closedUsers = append(m.closedUsers, userIDs...)
+	response := &client.UsersCloseResponse{}
+	for _, userID := range userIDs {
+		response.Users = append(response.Users, client.ClosedUser{UserId: userID, UserStatus: "Closed"})
+	}
+	return response, nil, nil
+}
+
+func newTestDormancyChecker(activityClient ActivityClient) *dormancyChecker {
+	checker := newDormancyChecker(activityClient, 90)
+	checker.now = func() time.Time { return dormancyTestNow }
+	return checker
+}
+
+// TestDormancyChecker_Check verifies dormancy is computed from logins, creation dates and envelope activity.
+func TestDormancyChecker_Check(t *testing.T) {
+	activityClient := &mockActivityClient{
+		lastSent: map[string]time.Time{
+			"sender": dormancyTestNow.AddDate(0, 0, -5),
+		},
+	}
+	checker := newTestDormancyChecker(activityClient)
+
+	tests := []struct {
+		name             string
+		user             client.User
+		wantDormant      bool
+		wantUndetermined bool
+		wantActivity     *time.Time
+	}{
+		{
+			name:         "recent login",
+			user:         client.User{UserId: "active", LastLogin: "2025-05-20T10:00:00.0000000Z"},
+			wantDormant:  false,
+			wantActivity: ptrTime(time.Date(2025, 5, 20, 10, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:         "old login without envelopes",
+			user:         client.User{UserId: "idle", LastLogin: "2024-01-01T00:00:00Z"},
+			wantDormant:  true,
+			wantActivity: ptrTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:         "old login with recent envelope",
+			user:         client.User{UserId: "sender", LastLogin: "2024-01-01T00:00:00Z"},
+			wantDormant:  false,
+			wantActivity: ptrTime(dormancyTestNow.AddDate(0, 0, -5)),
+		},
+		{
+			name:         "never logged in but recently created",
+			user:         client.User{UserId: "new", CreatedDateTime: "2025-05-30T00:00:00Z"},
+			wantDormant:  false,
+			wantActivity: ptrTime(time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:        "no activity at all",
+			user:        client.User{UserId: "ghost"},
+			wantDormant: true,
+		},
+		{
+			name:             "unparseable last login",
+			user:             client.User{UserId: "garbled", LastLogin: "not a date"},
+			wantDormant:      false,
+			wantUndetermined: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity, _, err := checker.Check(context.Background(), &tt.user)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDormant, activity.Dormant)
+			assert.Equal(t, tt.wantUndetermined, activity.Undetermined)
+			if tt.wantActivity == nil {
+				assert.Nil(t, activity.LastActivity)
+			} else {
+				require.NotNil(t, activity.LastActivity)
+				assert.True(t, tt.wantActivity.Equal(*activity.LastActivity))
+			}
+		})
+	}
+}
+
+// TestActionManager_CloseDormantUsers verifies the dry-run preview and the bulk close of dormant users.
+func TestActionManager_CloseDormantUsers(t *testing.T) {
+	users := []client.User{
+		{UserId: "active", UserStatus: "Active", LastLogin: "2025-05-20T10:00:00Z"},
+		{UserId: "idle", UserStatus: "Active", LastLogin: "2024-01-01T00:00:00Z"},
+		{UserId: "closed", UserStatus: "Closed", LastLogin: "2023-01-01T00:00:00Z"},
+		{UserId: "garbled", UserStatus: "Active", LastLogin: "not a date"},
+	}
+
+	t.Run("dry run only previews", func(t *testing.T) {
+		activityClient := &mockActivityClient{users: users}
+		manager := newActionManager(activityClient, newTestDormancyChecker(activityClient))
+
+		args, err := structpb.NewStruct(map[string]interface{}{"dry_run": true})
+		require.NoError(t, err)
+
+		id, status, response, _, err := manager.InvokeAction(context.Background(), actionCloseDormantUsers, args)
+		require.NoError(t, err)
+		assert.Equal(t, v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE, status)
+		assert.Empty(t, activityClient.closedUsers)
+		assert.Equal(t, float64(1), response.AsMap()["dormant_count"])
+		assert.Equal(t, []interface{}{"idle"}, response.AsMap()["dormant_users"])
+
+		recorded, name, _, _, err := manager.GetActionStatus(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE, recorded)
+		assert.Equal(t, actionCloseDormantUsers, name)
+	})
+
+	t.Run("closes dormant users", func(t *testing.T) {
+		activityClient := &mockActivityClient{users: users}
+		manager := newActionManager(activityClient, newTestDormancyChecker(activityClient))
+
+		args, err := structpb.NewStruct(map[string]interface{}{"dry_run": false})
+		require.NoError(t, err)
+
+		_, _, response, _, err := manager.InvokeAction(context.Background(), actionCloseDormantUsers, args)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"idle"}, activityClient.closedUsers)
+		assert.Equal(t, []interface{}{"idle"}, response.AsMap()["closed_user_ids"])
+	})
+
+	t.Run("fails when dormancy detection is disabled", func(t *testing.T) {
+		manager := newActionManager(&mockActivityClient{users: users}, nil)
+
+		_, status, _, _, err := manager.InvokeAction(context.Background(), actionCloseDormantUsers, nil)
+		require.Error(t, err)
+		assert.Equal(t, v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED, status)
+	})
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,65 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockEnvelopeClient implements EnvelopeClient returning envelopes across two pages.
+type mockEnvelopeClient struct {
+	pages map[string][]client.Envelope
+	since time.Time
+}
+
+func (m *mockEnvelopeClient) GetEnvelopes(ctx context.Context, userID string, since time.Time, options client.PageOptions) ([]client.Envelope, string, annotations.Annotations, error) {
+	m.since = since
+	switch options.PageToken {
+	case "":
+		return m.pages["first"], "page-2", nil, nil
+	case "page-2":
+		return m.pages["second"], "", nil, nil
+	default:
+		return nil, "", nil, fmt.Errorf("unexpected page token: %s", options.PageToken)
+	}
+}
+
+// TestEnvelopeUsageCounter_Count verifies envelopes are tallied by status across all pages of the window,
+// and only envelopes sent during the window count as sent.
+func TestEnvelopeUsageCounter_Count(t *testing.T) {
+	envelopeClient := &mockEnvelopeClient{
+		pages: map[string][]client.Envelope{
+			"first": {
+				{EnvelopeId: "1", Status: "completed", SentDateTime: "2025-05-01T00:00:00Z"},
+				{EnvelopeId: "2", Status: "sent", SentDateTime: "2025-05-02T00:00:00Z"},
+				{EnvelopeId: "3", Status: "created"},
+			},
+			"second": {
+				{EnvelopeId: "4", Status: "voided", SentDateTime: "2025-05-03T00:00:00Z"},
+				{EnvelopeId: "5", Status: "completed", SentDateTime: "2025-05-04T00:00:00Z"},
+				// Sent before the window and completed during it.
+				{EnvelopeId: "6", Status: "completed", SentDateTime: "2025-03-15T00:00:00Z"},
+			},
+		},
+	}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	counter := newEnvelopeUsageCounter(envelopeClient, 45)
+	counter.now = func() time.Time { return now }
+
+	usage, _, err := counter.Count(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 4, usage.Sent)
+	assert.Equal(t, 3, usage.Completed)
+	assert.Equal(t, 1, usage.Voided)
+	assert.True(t, now.AddDate(0, 0, -45).Equal(envelopeClient.since))
+
+	profile := usage.Profile(45)
+	assert.Equal(t, 4, profile["envelopes_sent"])
+	assert.Equal(t, 45, profile["envelope_usage_window_days"])
+}
@@ -3,6 +3,8 @@ package connector
 import (
 	"context"
 	"fmt"
+	"maps"
+	"strings"
 
 	"github.com/conductorone/baton-docusign/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
@@ -11,6 +13,8 @@ import (
 	"github.com/conductorone/baton-sdk/pkg/types/entitlement"
 	"github.com/conductorone/baton-sdk/pkg/types/grant"
 	"github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
 )
 
 // Entitlement value representing group membership.
@@ -18,16 +22,43 @@ const (
 	entitlementGroupMember = "member"
 )
 
+// Types of the groups DocuSign creates in every account.
+const (
+	groupTypeAdmin    = "adminGroup"
+	groupTypeEveryone = "everyoneGroup"
+)
+
+// Strategies for syncing group memberships. Group-centric pages through the users of every group,
+// while user-centric builds the memberships from the group list fetched with each user's details.
+const (
+	membershipStrategyGroupCentric = "group-centric"
+	membershipStrategyUserCentric  = "user-centric"
+)
+
 // groupsClientInterface defines the methods required for group-related API calls.
 type groupsClientInterface interface {
 	GetGroups(ctx context.Context, options client.PageOptions) ([]client.Group, string, annotations.Annotations, error)
 	GetGroupUsers(ctx context.Context, groupID string, options client.PageOptions) ([]client.User, string, annotations.Annotations, error)
+	AddGroupUsers(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error)
+	RemoveGroupUsers(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error)
+	CreateGroup(ctx context.Context, group client.GroupCreate) (*client.Group, annotations.Annotations, error)
+	DeleteGroup(ctx context.Context, groupID string) (annotations.Annotations, error)
+	AddGroupBrands(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error)
+	GetGroupBrands(ctx context.Context, groupID string) ([]client.Brand, annotations.Annotations, error)
+	GetUserDetails(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error)
+	UpdateUser(ctx context.Context, userID string, update client.UserUpdate) (annotations.Annotations, error)
 }
 
-// groupBuilder implements resource listing, entitlements, and grants for DocuSign groups.
+// groupBuilder implements resource listing, entitlements, grants, membership provisioning,
+// and group creation and deletion for DocuSign groups.
+// The built-in Administrators and Everyone groups are synced but cannot be provisioned.
+// With userCentric set, member grants are emitted by the userBuilder through userMembershipGrants.
 type groupBuilder struct {
-	resourceType *v2.ResourceType
-	client       groupsClientInterface
+	resourceType          *v2.ResourceType
+	client                groupsClientInterface
+	skipEveryoneGrants    bool
+	userCentric           bool
+	reactivateClosedUsers bool
 }
 
 // ResourceType returns the Baton resource type handled by this builder.
@@ -76,50 +107,65 @@ func (g *groupBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId
 }
 
 // Entitlements returns a "member" entitlement for each group, grantable to users.
+// The member entitlement of a system group is immutable.
 func (g *groupBuilder) Entitlements(ctx context.Context, groupResource *v2.Resource, pToken *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
 	annos := annotations.Annotations{}
-	ent := entitlement.NewAssignmentEntitlement(
-		groupResource,
-		entitlementGroupMember,
-		entitlement.WithGrantableTo(userResourceType),
-		entitlement.WithDisplayName(fmt.Sprintf("Member of %s", groupResource.DisplayName)),
-		entitlement.WithDescription(fmt.Sprintf("Member of %s group", groupResource.DisplayName)),
-	)
+	displayName := fmt.Sprintf("Member of %s", groupResource.DisplayName)
+	description := fmt.Sprintf("Member of %s group", groupResource.DisplayName)
+	options := []entitlement.EntitlementOption{entitlement.WithGrantableTo(userResourceType)}
+
+	if isSystemGroupType(groupTypeOf(groupResource)) {
+		options = append(options, entitlement.WithAnnotation(&v2.EntitlementImmutable{}))
+	}
+
+	options = append(options, entitlement.WithDisplayName(displayName), entitlement.WithDescription(description))
+	ent := entitlement.NewAssignmentEntitlement(groupResource, entitlementGroupMember, options...)
 	return []*v2.Entitlement{ent}, "", annos, nil
 }
 
 // Grants fetches users in the group and returns grants for the "member" entitlement.
+// Grants of system groups are immutable, and the Everyone group has none when skipEveryoneGrants is set.
+// The first page also links the group to its permission profile, expanding to the group members,
+// and emits the group's brand assignments, which are skipped when the brands cannot be read. Member grants are left to the userBuilder when userCentric is set.
 func (g *groupBuilder) Grants(ctx context.Context, groupResource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	var grants []*v2.Grant
+	annos := annotations.Annotations{}
+	if pToken.Token == "" {
+		if profileID := groupProfileString(groupResource, "permission_profile_id"); profileID != "" {
+			grants = append(grants, createGroupPermissionProfileGrant(groupResource, profileID))
+		}
+
+		brands, brandAnnos, err := g.client.GetGroupBrands(ctx, groupResource.Id.Resource)
+		annos = append(annos, brandAnnos...)
+		if err != nil {
+			ctxzap.Extract(ctx).Warn("docusign-connector: failed to get group brands, skipping its brand grants",
+				zap.String("group_id", groupResource.Id.Resource),
+				zap.Error(err),
+			)
+		} else {
+			grants = append(grants, createGroupBrandGrants(groupResource, brands)...)
+		}
+	}
+
+	groupType := groupTypeOf(groupResource)
+	if g.userCentric || g.skipsMembers(groupType) {
+		return grants, "", annos, nil
+	}
+
 	bag, pageToken, err := parsePageToken(pToken.Token, &v2.ResourceId{ResourceType: userResourceType.Id})
 	if err != nil {
 		return nil, "", nil, err
 	}
-	groupUsers, nextPageToken, annos, err := g.client.GetGroupUsers(ctx, groupResource.Id.Resource, client.PageOptions{
+	groupUsers, nextPageToken, usersAnnos, err := g.client.GetGroupUsers(ctx, groupResource.Id.Resource, client.PageOptions{
 		PageSize:  pToken.Size,
 		PageToken: pageToken,
 	})
+	annos = append(annos, usersAnnos...)
 	if err != nil {
 		return nil, "", nil, fmt.Errorf("docusign-connector: failed to get group users for %s: %w", groupResource.Id.Resource, err)
 	}
-	grants := make([]*v2.Grant, 0, len(groupUsers))
 	for _, user := range groupUsers {
-		userResource := &v2.Resource{
-			Id: &v2.ResourceId{
-				ResourceType: userResourceType.Id,
-				Resource:     user.UserId,
-			},
-		}
-		grants = append(grants, grant.NewGrant(
-			groupResource,
-			entitlementGroupMember,
-			userResource.Id,
-			grant.WithGrantMetadata(map[string]interface{}{
-				"group_id":   groupResource.Id.Resource,
-				"group_name": groupResource.DisplayName,
-				"user_id":    user.UserId,
-				"username":   user.UserName,
-			}),
-		))
+		grants = append(grants, newGroupMemberGrant(groupResource, groupType, user.UserId, user.UserName))
 	}
 
 	var outToken string
@@ -132,20 +178,380 @@ func (g *groupBuilder) Grants(ctx context.Context, groupResource *v2.Resource, p
 	return grants, outToken, annos, nil
 }
 
+// Grant adds the user to the group. Users that are already members are reported as such.
+// Membership of the Administrators and Everyone groups is managed by DocuSign and is refused.
+// With reactivateClosedUsers set, a closed user is reactivated before being added, and the grant metadata records the reactivation.
+// A reactivated user who already was a member still gets a grant, since the reactivation is what restores the access.
+func (g *groupBuilder) Grant(ctx context.Context, principal *v2.Resource, ent *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	if principal.Id.ResourceType != userResourceType.Id {
+		return nil, nil, fmt.Errorf("docusign-connector: only users can be added to a group")
+	}
+
+	groupID := ent.Resource.Id.Resource
+	userID := principal.Id.Resource
+
+	annos, err := g.ensureNotSystemGroup(ctx, ent.Resource)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	var reactivation map[string]interface{}
+	if g.reactivateClosedUsers {
+		detail, detailAnnos, err := g.client.GetUserDetails(ctx, userID)
+		annos = append(annos, detailAnnos...)
+		if err != nil {
+			return nil, annos, fmt.Errorf("docusign-connector: failed to get user %s: %w", userID, err)
+		}
+		var reactivateAnnos annotations.Annotations
+		reactivation, reactivateAnnos, err = reactivateClosedUser(ctx, g.client, detail)
+		annos = append(annos, reactivateAnnos...)
+		if err != nil {
+			return nil, annos, err
+		}
+	}
+
+	isMember, memberAnnos, err := g.isGroupMember(ctx, groupID, userID)
+	annos = append(annos, memberAnnos...)
+	if err != nil {
+		return nil, annos, err
+	}
+	switch {
+	case isMember && reactivation == nil:
+		annos.Update(&v2.GrantAlreadyExists{})
+		return nil, annos, nil
+	case !isMember:
+		response, addAnnos, err := g.client.AddGroupUsers(ctx, groupID, []string{userID})
+		annos = append(annos, addAnnos...)
+		if err != nil {
+			return nil, annos, fmt.Errorf("docusign-connector: failed to add %s to group %s: %w", userID, groupID, err)
+		}
+		if err := groupUserError(response, userID); err != nil {
+			return nil, annos, fmt.Errorf("docusign-connector: failed to add %s to group %s: %w", userID, groupID, err)
+		}
+	}
+
+	metadata := map[string]interface{}{
+		"group_id":   groupID,
+		"group_name": ent.Resource.DisplayName,
+		"user_id":    userID,
+	}
+	maps.Copy(metadata, reactivation)
+	membershipGrant := grant.NewGrant(ent.Resource, entitlementGroupMember, principal.Id, grant.WithGrantMetadata(metadata))
+
+	return []*v2.Grant{membershipGrant}, annos, nil
+}
+
+// Revoke removes the user from the group. Users that are not members are reported as already revoked.
+// Membership of the Administrators and Everyone groups is managed by DocuSign and is refused.
+func (g *groupBuilder) Revoke(ctx context.Context, membership *v2.Grant) (annotations.Annotations, error) {
+	if membership.Principal.Id.ResourceType != userResourceType.Id {
+		return nil, fmt.Errorf("docusign-connector: only users can be removed from a group")
+	}
+
+	groupID := membership.Entitlement.Resource.Id.Resource
+	userID := membership.Principal.Id.Resource
+
+	annos, err := g.ensureNotSystemGroup(ctx, membership.Entitlement.Resource)
+	if err != nil {
+		return annos, err
+	}
+
+	isMember, memberAnnos, err := g.isGroupMember(ctx, groupID, userID)
+	annos = append(annos, memberAnnos...)
+	if err != nil {
+		return annos, err
+	}
+	if !isMember {
+		annos.Update(&v2.GrantAlreadyRevoked{})
+		return annos, nil
+	}
+
+	response, removeAnnos, err := g.client.RemoveGroupUsers(ctx, groupID, []string{userID})
+	annos = append(annos, removeAnnos...)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to remove %s from group %s: %w", userID, groupID, err)
+	}
+	if err := groupUserError(response, userID); err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to remove %s from group %s: %w", userID, groupID, err)
+	}
+
+	return annos, nil
+}
+
+// Create creates a group named after the resource display name.
+// The group profile may set a "permission_profile_id" and "brand_ids", a list or a comma-separated string.
+func (g *groupBuilder) Create(ctx context.Context, groupResource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	name := groupResource.DisplayName
+	if name == "" {
+		return nil, nil, fmt.Errorf("docusign-connector: a group name is required")
+	}
+
+	request := client.GroupCreate{GroupName: name}
+	var brandIDs []string
+	if groupTrait, err := resource.GetGroupTrait(groupResource); err == nil {
+		profile := groupTrait.GetProfile().AsMap()
+		request.PermissionProfileId, _ = profile["permission_profile_id"].(string)
+		brandIDs = profileStringList(profile["brand_ids"])
+	}
+
+	group, annos, err := g.client.CreateGroup(ctx, request)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to create group %s: %w", name, err)
+	}
+
+	if len(brandIDs) > 0 {
+		brandAnnos, err := g.client.AddGroupBrands(ctx, group.GroupId, brandIDs)
+		annos = append(annos, brandAnnos...)
+		if err != nil {
+			// Remove the new group so that retrying the create doesn't leave a duplicate behind.
+			deleteAnnos, deleteErr := g.client.DeleteGroup(ctx, group.GroupId)
+			annos = append(annos, deleteAnnos...)
+			if deleteErr != nil {
+				return nil, annos, fmt.Errorf("docusign-connector: created group %s but failed to assign its brands (%w) and to delete it again: %w", group.GroupId, err, deleteErr)
+			}
+			return nil, annos, fmt.Errorf("docusign-connector: failed to assign brands to group %s, the group was deleted: %w", name, err)
+		}
+	}
+
+	created, err := parseIntoGroupResource(group)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return created, annos, nil
+}
+
+// Delete removes a group. The built-in Administrators and Everyone groups are refused.
+func (g *groupBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	groupID := resourceId.Resource
+
+	group, annos, err := g.findGroup(ctx, groupID)
+	if err != nil {
+		return annos, err
+	}
+	if group == nil {
+		return annos, fmt.Errorf("docusign-connector: group %s not found", groupID)
+	}
+	if isSystemGroup(group) {
+		return annos, fmt.Errorf("docusign-connector: cannot delete the built-in group %s", group.GroupName)
+	}
+
+	deleteAnnos, err := g.client.DeleteGroup(ctx, groupID)
+	annos = append(annos, deleteAnnos...)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to delete group %s: %w", groupID, err)
+	}
+
+	return annos, nil
+}
+
+// ensureNotSystemGroup returns an error when the group is one of the system groups, whose membership DocuSign manages.
+// The group type is read from the resource profile, or looked up when the resource does not carry it.
+func (g *groupBuilder) ensureNotSystemGroup(ctx context.Context, groupResource *v2.Resource) (annotations.Annotations, error) {
+	annos := annotations.Annotations{}
+	groupType := groupTypeOf(groupResource)
+	if groupType == "" {
+		group, findAnnos, err := g.findGroup(ctx, groupResource.Id.Resource)
+		annos = append(annos, findAnnos...)
+		if err != nil {
+			return annos, err
+		}
+		if group != nil {
+			groupType = group.GroupType
+		}
+	}
+
+	if isSystemGroupType(groupType) {
+		return annos, fmt.Errorf("docusign-connector: membership of the built-in group %s is managed by DocuSign", groupResource.Id.Resource)
+	}
+	return annos, nil
+}
+
+// findGroup pages through the account groups to find groupID. It returns nil when the group does not exist.
+func (g *groupBuilder) findGroup(ctx context.Context, groupID string) (*client.Group, annotations.Annotations, error) {
+	annos := annotations.Annotations{}
+	pageToken := ""
+	for {
+		groups, nextPageToken, pageAnnos, err := g.client.GetGroups(ctx, client.PageOptions{
+			PageSize:  client.DefaultPageSize,
+			PageToken: pageToken,
+		})
+		annos = append(annos, pageAnnos...)
+		if err != nil {
+			return nil, annos, fmt.Errorf("docusign-connector: failed to list groups: %w", err)
+		}
+
+		for i := range groups {
+			if groups[i].GroupId == groupID {
+				return &groups[i], annos, nil
+			}
+		}
+
+		if nextPageToken == "" {
+			return nil, annos, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// isGroupMember pages through the group users to find userID.
+func (g *groupBuilder) isGroupMember(ctx context.Context, groupID, userID string) (bool, annotations.Annotations, error) {
+	annos := annotations.Annotations{}
+	pageToken := ""
+	for {
+		users, nextPageToken, pageAnnos, err := g.client.GetGroupUsers(ctx, groupID, client.PageOptions{
+			PageSize:  client.DefaultPageSize,
+			PageToken: pageToken,
+		})
+		annos = append(annos, pageAnnos...)
+		if err != nil {
+			return false, annos, fmt.Errorf("docusign-connector: failed to get group users for %s: %w", groupID, err)
+		}
+
+		for _, user := range users {
+			if user.UserId == userID {
+				return true, annos, nil
+			}
+		}
+
+		if nextPageToken == "" {
+			return false, annos, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// groupUserError returns the error DocuSign reported for userID in a group membership response, if any.
+func groupUserError(response *client.GroupUsersResponse, userID string) error {
+	if response == nil {
+		return nil
+	}
+	for _, user := range response.Users {
+		if user.UserId == userID && user.ErrorDetails != nil {
+			return fmt.Errorf("%s: %s", user.ErrorDetails.ErrorCode, user.ErrorDetails.Message)
+		}
+	}
+	return nil
+}
+
+// isSystemGroup reports whether the group is one DocuSign creates in every account.
+func isSystemGroup(group *client.Group) bool {
+	return isSystemGroupType(group.GroupType)
+}
+
+// isSystemGroupType reports whether the group type is the one of a system group.
+func isSystemGroupType(groupType string) bool {
+	return groupType == groupTypeAdmin || groupType == groupTypeEveryone
+}
+
+// groupTypeOf returns the group type stored in the profile of a group resource, or "" when it is not available.
+func groupTypeOf(groupResource *v2.Resource) string {
+	return groupProfileString(groupResource, "group_type")
+}
+
+// groupProfileString returns a string field of the profile of a group resource, or "" when it is not available.
+func groupProfileString(groupResource *v2.Resource, key string) string {
+	groupTrait, err := resource.GetGroupTrait(groupResource)
+	if err != nil {
+		return ""
+	}
+	value, _ := groupTrait.GetProfile().AsMap()[key].(string)
+	return value
+}
+
+// profileStringList reads a profile value holding a list of strings or a comma-separated string.
+func profileStringList(value interface{}) []string {
+	var values []string
+	switch v := value.(type) {
+	case string:
+		values = strings.Split(v, ",")
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// userMembershipGrants returns the member grants of every group in the user's group list.
+// It produces the same grants as Grants does when paging through the users of each group.
+func (g *groupBuilder) userMembershipGrants(user *client.UserDetail) []*v2.Grant {
+	var grants []*v2.Grant
+	for _, group := range user.GroupList {
+		if g.skipsMembers(group.GroupType) {
+			continue
+		}
+		groupResource := &v2.Resource{
+			Id: &v2.ResourceId{
+				ResourceType: groupResourceType.Id,
+				Resource:     group.GroupId,
+			},
+			DisplayName: group.GroupName,
+		}
+		grants = append(grants, newGroupMemberGrant(groupResource, group.GroupType, user.UserID, user.UserName))
+	}
+	return grants
+}
+
+// skipsMembers reports whether the member grants of groups of this type are left out of the sync.
+func (g *groupBuilder) skipsMembers(groupType string) bool {
+	return g.skipEveryoneGrants && groupType == groupTypeEveryone
+}
+
 // newGroupBuilder constructs a groupBuilder with the provided API client.
-func newGroupBuilder(client *client.Client) *groupBuilder {
+// skipEveryoneGrants drops the member grants of the Everyone group, which every user holds,
+// and userCentric leaves member grants to the userBuilder.
+func newGroupBuilder(client *client.Client, skipEveryoneGrants bool, userCentric bool) *groupBuilder {
 	return &groupBuilder{
-		resourceType: groupResourceType,
-		client:       client,
+		resourceType:       groupResourceType,
+		client:             client,
+		skipEveryoneGrants: skipEveryoneGrants,
+		userCentric:        userCentric,
 	}
 }
 
+// withClosedUserReactivation makes Grant reactivate closed users before adding them to a group.
+func (g *groupBuilder) withClosedUserReactivation() *groupBuilder {
+	g.reactivateClosedUsers = true
+	return g
+}
+
+// newGroupMemberGrant builds the grant of a group's "member" entitlement to a user.
+// Grants of system groups are immutable.
+func newGroupMemberGrant(groupResource *v2.Resource, groupType, userID, username string) *v2.Grant {
+	grantOptions := []grant.GrantOption{
+		grant.WithGrantMetadata(map[string]interface{}{
+			"group_id":   groupResource.Id.Resource,
+			"group_name": groupResource.DisplayName,
+			"user_id":    userID,
+			"username":   username,
+		}),
+	}
+	if isSystemGroupType(groupType) {
+		grantOptions = append(grantOptions, grant.WithAnnotation(&v2.GrantImmutable{}))
+	}
+	return grant.NewGrant(groupResource, entitlementGroupMember, makeUserSubjectID(userID), grantOptions...)
+}
+
 // parseIntoGroupResource maps a client.Group to a Baton v2.Resource.
 func parseIntoGroupResource(group *client.Group) (*v2.Resource, error) {
 	profile := map[string]interface{}{
-		"group_name":  group.GroupName,
-		"group_type":  group.GroupType,
-		"users_count": group.UsersCount,
+		"group_name":   group.GroupName,
+		"group_type":   group.GroupType,
+		"users_count":  group.UsersCount,
+		"system_group": isSystemGroup(group),
+	}
+	if group.PermissionProfileId != "" {
+		profile["permission_profile_id"] = group.PermissionProfileId
 	}
 
 	return resource.NewGroupResource(
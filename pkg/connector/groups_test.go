@@ -222,3 +222,435 @@ func newTestGroupBuilder(client groupsClientInterface) *groupBuilder {
 		client: client,
 	}
 }
+
+// TestGroupBuilder_Grant tests adding a user to a group.
+func TestGroupBuilder_Grant(t *testing.T) {
+	ctx := context.Background()
+	groupResource := &v2.Resource{Id: &v2.ResourceId{ResourceType: groupResourceType.Id, Resource: "g1"}, DisplayName: "Legal"}
+	ent := &v2.Entitlement{Resource: groupResource, Slug: entitlementGroupMember}
+	userRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}
+
+	t.Run("adds the user", func(t *testing.T) {
+		var added []string
+		mockClient := &test.MockClient{
+			AddGroupUsersFunc: func(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error) {
+				assert.Equal(t, "g1", groupID)
+				added = append(added, userIDs...)
+				return &client.GroupUsersResponse{Users: []client.GroupUser{{UserId: "u1"}}}, nil, nil
+			},
+		}
+
+		grants, _, err := newTestGroupBuilder(mockClient).Grant(ctx, userRes, ent)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, []string{"u1"}, added)
+		assert.Equal(t, "group:g1:member", grants[0].Entitlement.Id)
+	})
+
+	t.Run("treats existing members as success", func(t *testing.T) {
+		mockClient := &test.MockClient{
+			GetGroupUsersFunc: func(ctx context.Context, groupID string, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+				if opts.PageToken == "" {
+					return []client.User{{UserId: "u2"}}, "page2", nil, nil
+				}
+				return []client.User{{UserId: "u1"}}, "", nil, nil
+			},
+			AddGroupUsersFunc: func(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error) {
+				t.Fatal("existing members must not be added again")
+				return nil, nil, nil
+			},
+		}
+
+		grants, annos, err := newTestGroupBuilder(mockClient).Grant(ctx, userRes, ent)
+		require.NoError(t, err)
+		assert.Empty(t, grants)
+		assert.True(t, annos.Contains(&v2.GrantAlreadyExists{}))
+	})
+
+	t.Run("reports per-user errors", func(t *testing.T) {
+		mockClient := &test.MockClient{
+			AddGroupUsersFunc: func(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error) {
+				return &client.GroupUsersResponse{Users: []client.GroupUser{{
+					UserId:       "u1",
+					ErrorDetails: &client.ErrorDetails{ErrorCode: "USER_NOT_FOUND", Message: "The user was not found."},
+				}}}, nil, nil
+			},
+		}
+
+		_, _, err := newTestGroupBuilder(mockClient).Grant(ctx, userRes, ent)
+		require.ErrorContains(t, err, "USER_NOT_FOUND")
+	})
+
+	t.Run("rejects non-user principals", func(t *testing.T) {
+		_, _, err := newTestGroupBuilder(&test.MockClient{}).Grant(ctx, groupResource, ent)
+		require.Error(t, err)
+	})
+}
+
+// TestGroupBuilder_Revoke tests removing a user from a group.
+func TestGroupBuilder_Revoke(t *testing.T) {
+	ctx := context.Background()
+	membership := &v2.Grant{
+		Entitlement: &v2.Entitlement{
+			Resource: &v2.Resource{Id: &v2.ResourceId{ResourceType: groupResourceType.Id, Resource: "g1"}},
+			Slug:     entitlementGroupMember,
+		},
+		Principal: &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}},
+	}
+
+	t.Run("removes the user", func(t *testing.T) {
+		var removed []string
+		mockClient := &test.MockClient{
+			GetGroupUsersFunc: func(ctx context.Context, groupID string, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+				return []client.User{{UserId: "u1"}}, "", nil, nil
+			},
+			RemoveGroupUsersFunc: func(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error) {
+				assert.Equal(t, "g1", groupID)
+				removed = append(removed, userIDs...)
+				return &client.GroupUsersResponse{}, nil, nil
+			},
+		}
+
+		_, err := newTestGroupBuilder(mockClient).Revoke(ctx, membership)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1"}, removed)
+	})
+
+	t.Run("treats non-members as success", func(t *testing.T) {
+		mockClient := &test.MockClient{
+			RemoveGroupUsersFunc: func(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error) {
+				t.Fatal("non-members must not be removed")
+				return nil, nil, nil
+			},
+		}
+
+		annos, err := newTestGroupBuilder(mockClient).Revoke(ctx, membership)
+		require.NoError(t, err)
+		assert.True(t, annos.Contains(&v2.GrantAlreadyRevoked{}))
+	})
+}
+
+// TestGroupBuilder_Create tests creating a group with a permission profile and brands.
+func TestGroupBuilder_Create(t *testing.T) {
+	var request client.GroupCreate
+	var brands []string
+	mockClient := &test.MockClient{
+		CreateGroupFunc: func(ctx context.Context, group client.GroupCreate) (*client.Group, annotations.Annotations, error) {
+			request = group
+			return &client.Group{GroupId: "g9", GroupName: group.GroupName, GroupType: "customGroup"}, nil, nil
+		},
+		AddGroupBrandsFunc: func(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error) {
+			assert.Equal(t, "g9", groupID)
+			brands = brandIDs
+			return nil, nil
+		},
+	}
+
+	groupResource, err := resource.NewGroupResource(
+		"Project Apollo",
+		groupResourceType,
+		"",
+		[]resource.GroupTraitOption{resource.WithGroupProfile(map[string]interface{}{
+			"permission_profile_id": "1002",
+			"brand_ids":             "b1, b2",
+		})},
+	)
+	require.NoError(t, err)
+
+	created, _, err := newTestGroupBuilder(mockClient).Create(context.Background(), groupResource)
+	require.NoError(t, err)
+	assert.Equal(t, "g9", created.Id.Resource)
+	assert.Equal(t, client.GroupCreate{GroupName: "Project Apollo", PermissionProfileId: "1002"}, request)
+	assert.Equal(t, []string{"b1", "b2"}, brands)
+
+	t.Run("requires a name", func(t *testing.T) {
+		_, _, err := newTestGroupBuilder(mockClient).Create(context.Background(), &v2.Resource{Id: &v2.ResourceId{ResourceType: groupResourceType.Id}})
+		require.Error(t, err)
+	})
+
+	t.Run("deletes the group when its brands cannot be assigned", func(t *testing.T) {
+		var deleted []string
+		failingClient := &test.MockClient{
+			CreateGroupFunc: mockClient.CreateGroupFunc,
+			AddGroupBrandsFunc: func(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error) {
+				return nil, fmt.Errorf("brand not found")
+			},
+			DeleteGroupFunc: func(ctx context.Context, groupID string) (annotations.Annotations, error) {
+				deleted = append(deleted, groupID)
+				return nil, nil
+			},
+		}
+
+		created, _, err := newTestGroupBuilder(failingClient).Create(context.Background(), groupResource)
+		require.Error(t, err)
+		assert.Nil(t, created)
+		assert.Equal(t, []string{"g9"}, deleted)
+	})
+}
+
+// TestGroupBuilder_Delete tests deleting groups and refusing to delete the built-in ones.
+func TestGroupBuilder_Delete(t *testing.T) {
+	var deleted []string
+	mockClient := &test.MockClient{
+		GetGroupsFunc: func(ctx context.Context, opts client.PageOptions) ([]client.Group, string, annotations.Annotations, error) {
+			return []client.Group{
+				{GroupId: "1", GroupName: "Administrators", GroupType: "adminGroup"},
+				{GroupId: "2", GroupName: "Everyone", GroupType: "everyoneGroup"},
+				{GroupId: "3", GroupName: "Legal", GroupType: "customGroup"},
+			}, "", nil, nil
+		},
+		DeleteGroupFunc: func(ctx context.Context, groupID string) (annotations.Annotations, error) {
+			deleted = append(deleted, groupID)
+			return nil, nil
+		},
+	}
+	builder := newTestGroupBuilder(mockClient)
+	ctx := context.Background()
+
+	_, err := builder.Delete(ctx, &v2.ResourceId{ResourceType: groupResourceType.Id, Resource: "3"})
+	require.NoError(t, err)
+
+	for _, id := range []string{"1", "2", "404"} {
+		_, err := builder.Delete(ctx, &v2.ResourceId{ResourceType: groupResourceType.Id, Resource: id})
+		require.Error(t, err, id)
+	}
+	assert.Equal(t, []string{"3"}, deleted)
+}
+
+// TestGroupBuilder_SystemGroups tests the special handling of the Administrators and Everyone groups.
+func TestGroupBuilder_SystemGroups(t *testing.T) {
+	ctx := context.Background()
+	newSystemGroup := func(t *testing.T, group client.Group) *v2.Resource {
+		groupResource, err := parseIntoGroupResource(&group)
+		require.NoError(t, err)
+		return groupResource
+	}
+	admins := newSystemGroup(t, client.Group{GroupId: "1", GroupName: "Administrators", GroupType: groupTypeAdmin})
+	everyone := newSystemGroup(t, client.Group{GroupId: "2", GroupName: "Everyone", GroupType: groupTypeEveryone})
+	legal := newSystemGroup(t, client.Group{GroupId: "3", GroupName: "Legal", GroupType: "customGroup"})
+
+	mockClient := &test.MockClient{
+		GetGroupUsersFunc: func(ctx context.Context, groupID string, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+			return []client.User{{UserId: "u1"}}, "", nil, nil
+		},
+		GetGroupsFunc: func(ctx context.Context, opts client.PageOptions) ([]client.Group, string, annotations.Annotations, error) {
+			return []client.Group{{GroupId: "1", GroupName: "Administrators", GroupType: groupTypeAdmin}}, "", nil, nil
+		},
+	}
+	builder := newTestGroupBuilder(mockClient)
+
+	t.Run("tags system groups", func(t *testing.T) {
+		groupTrait, err := resource.GetGroupTrait(admins)
+		require.NoError(t, err)
+		assert.True(t, groupTrait.Profile.GetFields()["system_group"].GetBoolValue())
+
+		groupTrait, err = resource.GetGroupTrait(legal)
+		require.NoError(t, err)
+		assert.False(t, groupTrait.Profile.GetFields()["system_group"].GetBoolValue())
+	})
+
+	t.Run("marks system group member entitlements immutable", func(t *testing.T) {
+		ents, _, _, err := builder.Entitlements(ctx, admins, pToken)
+		require.NoError(t, err)
+		require.Len(t, ents, 1)
+		assert.Equal(t, "Member of Administrators", ents[0].DisplayName)
+		entAnnos := annotations.Annotations(ents[0].Annotations)
+		assert.True(t, entAnnos.Contains(&v2.EntitlementImmutable{}))
+
+		ents, _, _, err = builder.Entitlements(ctx, legal, pToken)
+		require.NoError(t, err)
+		entAnnos = annotations.Annotations(ents[0].Annotations)
+		assert.False(t, entAnnos.Contains(&v2.EntitlementImmutable{}))
+	})
+
+	t.Run("marks system group grants immutable", func(t *testing.T) {
+		grants, _, _, err := builder.Grants(ctx, everyone, &pagination.Token{})
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		grantAnnos := annotations.Annotations(grants[0].Annotations)
+		assert.True(t, grantAnnos.Contains(&v2.GrantImmutable{}))
+	})
+
+	t.Run("skips Everyone grants when configured", func(t *testing.T) {
+		skipping := newTestGroupBuilder(mockClient)
+		skipping.skipEveryoneGrants = true
+
+		grants, _, _, err := skipping.Grants(ctx, everyone, &pagination.Token{})
+		require.NoError(t, err)
+		assert.Empty(t, grants)
+
+		grants, _, _, err = skipping.Grants(ctx, legal, &pagination.Token{})
+		require.NoError(t, err)
+		assert.Len(t, grants, 1)
+	})
+
+	t.Run("refuses membership changes", func(t *testing.T) {
+		userRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u2"}}
+		_, _, err := builder.Grant(ctx, userRes, &v2.Entitlement{Resource: everyone, Slug: entitlementGroupMember})
+		require.Error(t, err)
+
+		// Without a profile on the resource, the group type is looked up.
+		_, err = builder.Revoke(ctx, &v2.Grant{
+			Entitlement: &v2.Entitlement{Resource: &v2.Resource{Id: admins.Id}, Slug: entitlementGroupMember},
+			Principal:   userRes,
+		})
+		require.Error(t, err)
+	})
+}
+
+// TestGroupBuilder_Grants_PermissionProfile tests the link from a group to its permission profile.
+func TestGroupBuilder_Grants_PermissionProfile(t *testing.T) {
+	mockClient := &test.MockClient{
+		GetGroupUsersFunc: func(ctx context.Context, groupID string, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+			return []client.User{{UserId: "u1"}}, "next_token", nil, nil
+		},
+	}
+	builder := newTestGroupBuilder(mockClient)
+	groupResource, err := parseIntoGroupResource(&client.Group{GroupId: "g1", GroupName: "Legal", GroupType: "customGroup", PermissionProfileId: "1002"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	grants, nextToken, _, err := builder.Grants(ctx, groupResource, &pagination.Token{})
+	require.NoError(t, err)
+	require.Len(t, grants, 2)
+
+	profileGrant := grants[0]
+	assert.Equal(t, "permission_profile:1002:assigned", profileGrant.Entitlement.Id)
+	assert.Equal(t, groupResource.Id.Resource, profileGrant.Principal.Id.Resource)
+
+	grantAnnos := annotations.Annotations(profileGrant.Annotations)
+	expandable := &v2.GrantExpandable{}
+	ok, err := grantAnnos.Pick(expandable)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []string{"group:g1:member"}, expandable.EntitlementIds)
+
+	metadata := &v2.GrantMetadata{}
+	ok, err = grantAnnos.Pick(metadata)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "g1", metadata.Metadata.GetFields()["source_group_id"].GetStringValue())
+	assert.Equal(t, "Legal", metadata.Metadata.GetFields()["source_group_name"].GetStringValue())
+
+	// The link is only emitted with the first page of members.
+	grants, _, _, err = builder.Grants(ctx, groupResource, &pagination.Token{Token: nextToken})
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, "group:g1:member", grants[0].Entitlement.Id)
+}
+
+// TestGroupBuilder_MembershipStrategies verifies the user-centric strategy produces the same member grants
+// as paging through the users of each group, including immutable system group grants and skipped Everyone grants.
+func TestGroupBuilder_MembershipStrategies(t *testing.T) {
+	groups := []client.Group{
+		{GroupId: "g1", GroupName: "Developers", GroupType: "customGroup"},
+		{GroupId: "g2", GroupName: "Administrators", GroupType: groupTypeAdmin},
+		{GroupId: "g3", GroupName: "Everyone", GroupType: groupTypeEveryone},
+	}
+	users := []client.User{
+		{UserId: "u1", UserName: "Alice"},
+		{UserId: "u2", UserName: "Bob"},
+	}
+	members := map[string][]string{
+		"g1": {"u1", "u2"},
+		"g2": {"u1"},
+		"g3": {"u1", "u2"},
+	}
+
+	groupsClient := &test.MockClient{
+		GetGroupUsersFunc: func(ctx context.Context, groupID string, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+			var groupUsers []client.User
+			for _, user := range users {
+				for _, memberID := range members[groupID] {
+					if user.UserId == memberID {
+						groupUsers = append(groupUsers, user)
+					}
+				}
+			}
+			return groupUsers, "", nil, nil
+		},
+	}
+	usersClient := &mockClient{
+		getUserDetailsFunc: func(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+			detail := &client.UserDetail{UserID: userID}
+			for _, user := range users {
+				if user.UserId == userID {
+					detail.UserName = user.UserName
+				}
+			}
+			for _, group := range groups {
+				for _, memberID := range members[group.GroupId] {
+					if memberID == userID {
+						detail.GroupList = append(detail.GroupList, group)
+					}
+				}
+			}
+			return detail, nil, nil
+		},
+	}
+
+	for _, skipEveryone := range []bool{false, true} {
+		t.Run(fmt.Sprintf("skip everyone %t", skipEveryone), func(t *testing.T) {
+			groupCentric := newTestGroupBuilder(groupsClient)
+			groupCentric.skipEveryoneGrants = skipEveryone
+
+			var expected []*v2.Grant
+			for _, group := range groups {
+				groupCopy := group
+				groupResource, err := parseIntoGroupResource(&groupCopy)
+				require.NoError(t, err)
+				grants, _, _, err := groupCentric.Grants(context.Background(), groupResource, &pagination.Token{})
+				require.NoError(t, err)
+				expected = append(expected, grants...)
+			}
+
+			userCentric := newTestGroupBuilder(groupsClient)
+			userCentric.skipEveryoneGrants = skipEveryone
+			userCentric.userCentric = true
+			for _, group := range groups {
+				groupCopy := group
+				groupResource, err := parseIntoGroupResource(&groupCopy)
+				require.NoError(t, err)
+				grants, _, _, err := userCentric.Grants(context.Background(), groupResource, &pagination.Token{})
+				require.NoError(t, err)
+				assert.Empty(t, grants)
+			}
+
+			builder := &userBuilder{resourceType: userResourceType, client: usersClient, permissionBuilder: newPermissionBuilder(nil, nil, false), groupBuilder: userCentric}
+			var actual []*v2.Grant
+			for _, user := range users {
+				userRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: user.UserId}}
+				grants, _, _, err := builder.Grants(context.Background(), userRes, nil)
+				require.NoError(t, err)
+				for _, g := range grants {
+					if g.Entitlement.Resource.Id.ResourceType == groupResourceType.Id {
+						actual = append(actual, g)
+					}
+				}
+			}
+
+			if skipEveryone {
+				assert.Len(t, expected, 3)
+			} else {
+				assert.Len(t, expected, 5)
+			}
+			assert.ElementsMatch(t, summarizeGrants(t, expected), summarizeGrants(t, actual))
+		})
+	}
+}
+
+// summarizeGrants reduces grants to the fields that identify them, so grants built from different sources can be compared.
+func summarizeGrants(t *testing.T, grants []*v2.Grant) []string {
+	summaries := make([]string, 0, len(grants))
+	for _, g := range grants {
+		annos := annotations.Annotations(g.Annotations)
+		metadata := &v2.GrantMetadata{}
+		_, err := annos.Pick(metadata)
+		require.NoError(t, err)
+		metadataJSON, err := json.Marshal(metadata.Metadata.AsMap())
+		require.NoError(t, err)
+		immutable := annos.Contains(&v2.GrantImmutable{})
+		summaries = append(summaries, fmt.Sprintf("%s|%s|%s|%t|%s", g.Id, g.Entitlement.Id, g.Principal.Id.Resource, immutable, metadataJSON))
+	}
+	return summaries
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/conductorone/baton-docusign/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
-	"github.com/conductorone/baton-sdk/pkg/types/grant"
 )
 
 // parsePageToken deserializes the Baton token and returns the Bag and page number for upstream.
@@ -26,54 +25,100 @@ func parsePageToken(i string, resourceID *v2.ResourceId) (*pagination.Bag, strin
 	return b, b.PageToken(), nil
 }
 
-// createUserGrants generates grants for a single user based on their settings.
-func createUserGrants(permissionResource *v2.Resource, user *client.UserDetail) ([]*v2.Grant, error) {
-	settingsMap, err := parseUserSettings(user.UserSettings)
-	if err != nil {
-		return nil, err
+// Values of the "origin" grant metadata telling where a permission or permission profile comes from.
+const (
+	permissionOriginProfile  = "permission_profile"
+	permissionOriginOverride = "user_override"
+	permissionOriginGroup    = "group"
+)
+
+// createUserGrants generates grants for a single user based on their settings and the permission catalog,
+// on the permission resources of the builder's mode.
+// profileSettings are the settings of the user's permission profile, used to tell profile-derived
+// permissions from user-level overrides; the origin is omitted when they are nil.
+// extraMetadata is merged into the metadata of every grant.
+func createUserGrants(
+	permissions *permissionBuilder,
+	user *client.UserDetail,
+	settingsMap map[string]interface{},
+	profileSettings map[string]interface{},
+	extraMetadata map[string]interface{},
+) []*v2.Grant {
+	catalog := catalogOrDefault(permissions.catalog)
+	var profilePermissions map[string]string
+	if profileSettings != nil {
+		_, profilePermissions = catalog.Granted(profileSettings)
 	}
 
-	var grants []*v2.Grant
-	for _, mapping := range fieldToPermissionMappings {
-		if value, exists := settingsMap[mapping.FieldName]; exists {
-			if hasPermission, accessLevel := checkPermissionValue(value); hasPermission {
-				subject := makeUserSubjectID(user.UserID)
-				grants = append(grants, grant.NewGrant(
-					permissionResource,
-					mapping.PermissionID,
-					subject,
-					grant.WithGrantMetadata(createGrantMetadata(user, accessLevel)),
-				))
+	slugs, accessLevels := catalog.Granted(settingsMap)
+
+	grants := make([]*v2.Grant, 0, len(slugs))
+	for _, slug := range slugs {
+		metadata := createGrantMetadata(user, accessLevels[slug], extraMetadata)
+		if profilePermissions != nil {
+			metadata["origin"] = permissionOriginOverride
+			if _, ok := profilePermissions[slug]; ok {
+				metadata["origin"] = permissionOriginProfile
 			}
 		}
+
+		grants = append(grants, permissions.newPermissionGrant(slug, makeUserSubjectID(user.UserID), metadata))
 	}
 
-	return grants, nil
+	return grants
 }
 
 // createGrantMetadata creates metadata for permission grants.
-func createGrantMetadata(user *client.UserDetail, accessLevel string) map[string]interface{} {
-	return map[string]interface{}{
+func createGrantMetadata(user *client.UserDetail, accessLevel string, extraMetadata map[string]interface{}) map[string]interface{} {
+	metadata := map[string]interface{}{
 		"source":       "DocuSign",
 		"profile":      user.PermissionProfileName,
 		"user_id":      user.UserID,
 		"username":     user.UserName,
 		"access_level": accessLevel,
 	}
+	for key, value := range extraMetadata {
+		metadata[key] = value
+	}
+	return metadata
+}
+
+// lookupSetting resolves a dot-separated setting path in a settings map.
+func lookupSetting(settingsMap map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = settingsMap
+	for _, key := range strings.Split(path, ".") {
+		nested, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = nested[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// nestedSetting builds the settings payload setting the dot-separated path to value.
+func nestedSetting(path string, value string) map[string]interface{} {
+	keys := strings.Split(path, ".")
+	var setting interface{} = value
+	for i := len(keys) - 1; i >= 0; i-- {
+		setting = map[string]interface{}{keys[i]: setting}
+	}
+	return setting.(map[string]interface{})
 }
 
-// checkPermissionValue validates and normalizes a permission value.
-func checkPermissionValue(value interface{}) (bool, string) {
+// normalizePermissionValue returns a setting value as a lower case string.
+func normalizePermissionValue(value interface{}) string {
 	switch v := value.(type) {
 	case string:
-		lowerVal := strings.ToLower(v)
-		return validPermissionValues[lowerVal], lowerVal
+		return strings.ToLower(v)
 	case bool:
 		if v {
-			return true, "true"
+			return "true"
 		}
-		return false, "false"
+		return "false"
 	default:
-		return false, ""
+		return ""
 	}
 }
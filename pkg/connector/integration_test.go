@@ -45,8 +45,8 @@ func TestUserBuilderList(t *testing.T) {
 	ctx := context.Background()
 	client := initClient(t)
 
-	pb := newPermissionBuilder(client)
-	user := newUserBuilder(client, pb)
+	pb := newPermissionBuilder(client, nil, false)
+	user := newUserBuilder(client, pb, nil, userEnrichers{})
 	resource, nextToken, _, err := user.List(ctx, parentResourceID, pToken)
 
 	assert.NoError(t, err)
@@ -60,7 +60,7 @@ func TestGroupBuilderList(t *testing.T) {
 	ctx := context.Background()
 	client := initClient(t)
 
-	group := newGroupBuilder(client)
+	group := newGroupBuilder(client, false, false)
 	resource, nextToken, _, err := group.List(ctx, parentResourceID, pToken)
 
 	assert.NoError(t, err)
@@ -74,7 +74,7 @@ func TestPermissionBuilderList(t *testing.T) {
 	ctx := context.Background()
 	client := initClient(t)
 
-	permission := newPermissionBuilder(client)
+	permission := newPermissionBuilder(client, nil, false)
 	resource, nextToken, _, err := permission.List(ctx, parentResourceID, pToken)
 
 	assert.NoError(t, err)
@@ -89,8 +89,8 @@ func TestUserBuilderGrants(t *testing.T) {
 	ctx := context.Background()
 	client := initClient(t)
 
-	pb := newPermissionBuilder(client)
-	user := newUserBuilder(client, pb)
+	pb := newPermissionBuilder(client, nil, false)
+	user := newUserBuilder(client, pb, nil, userEnrichers{})
 
 	users, _, _, err := user.List(ctx, parentResourceID, pToken)
 	assert.NoError(t, err)
@@ -0,0 +1,437 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	"github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+// Entitlement value representing a user's assignment to a permission profile.
+const (
+	entitlementPermissionProfileAssigned = "assigned"
+)
+
+// builtInPermissionProfiles contains the profiles DocuSign creates in every account, which cannot be deleted.
+var builtInPermissionProfiles = []string{
+	"DS Admin",
+	"DocuSign Sender",
+	"DocuSign Viewer",
+}
+
+// PermissionProfileClient defines the methods required for permission profile API calls.
+type PermissionProfileClient interface {
+	GetPermissionProfiles(ctx context.Context) ([]client.PermissionProfile, annotations.Annotations, error)
+	GetUsers(ctx context.Context, options client.PageOptions) ([]client.User, string, annotations.Annotations, error)
+	GetUserDetails(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error)
+	UpdateUser(ctx context.Context, userID string, update client.UserUpdate) (annotations.Annotations, error)
+	CreatePermissionProfile(ctx context.Context, request client.PermissionProfileCreateRequest) (*client.PermissionProfile, annotations.Annotations, error)
+	DeletePermissionProfile(ctx context.Context, profileID string) (annotations.Annotations, error)
+	HasPermissionProfileUsers(ctx context.Context, profileID string) (bool, annotations.Annotations, error)
+}
+
+// permissionProfileBuilder syncs DocuSign permission profiles as roles and assigns them to users.
+// Assignment grants are emitted by the userBuilder, which already reads each user's profile ID.
+// Each profile is granted the permissions its settings enable, expanded to the users assigned to it.
+type permissionProfileBuilder struct {
+	resourceType          *v2.ResourceType
+	client                PermissionProfileClient
+	fallbackProfileID     string
+	permissions           *permissionBuilder
+	reactivateClosedUsers bool
+}
+
+// ResourceType returns the Baton resource type handled by this builder.
+func (p *permissionProfileBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return permissionProfileResourceType
+}
+
+// List fetches all permission profiles of the account and converts them to role resources.
+func (p *permissionProfileBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	profiles, annos, err := p.client.GetPermissionProfiles(ctx)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("docusign-connector: failed to list permission profiles: %w", err)
+	}
+
+	resources := make([]*v2.Resource, 0, len(profiles))
+	for _, profile := range profiles {
+		profileCopy := profile
+		profileResource, err := parseIntoPermissionProfileResource(&profileCopy)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		resources = append(resources, profileResource)
+	}
+
+	return resources, "", annos, nil
+}
+
+// Entitlements returns an "assigned" entitlement for each permission profile, grantable to users.
+// Groups also hold it through the permission profile they carry.
+func (p *permissionProfileBuilder) Entitlements(ctx context.Context, profileResource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	ent := entitlement.NewAssignmentEntitlement(
+		profileResource,
+		entitlementPermissionProfileAssigned,
+		entitlement.WithGrantableTo(userResourceType, groupResourceType),
+		entitlement.WithDisplayName(fmt.Sprintf("%s Permission Profile", profileResource.DisplayName)),
+		entitlement.WithDescription(fmt.Sprintf("Assigned the %s permission profile in DocuSign", profileResource.DisplayName)),
+	)
+	return []*v2.Entitlement{ent}, "", annotations.Annotations{}, nil
+}
+
+// Grants links the profile to the permissions its settings enable. The grants are expandable,
+// so users assigned to the profile inherit its permissions; profile assignments themselves are emitted by the userBuilder.
+// The settings are read from the role profile List stored them in, so no profile is fetched again.
+func (p *permissionProfileBuilder) Grants(ctx context.Context, profileResource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	roleTrait, err := resource.GetRoleTrait(profileResource)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("docusign-connector: failed to read permission profile %s: %w", profileResource.Id.Resource, err)
+	}
+	settings, _ := roleTrait.GetProfile().AsMap()["settings"].(map[string]interface{})
+
+	return createPermissionProfileExpansionGrants(p.permissions, profileResource, settings), "", nil, nil
+}
+
+// Grant moves the user to the permission profile. DocuSign users have exactly one profile,
+// so the returned grant records the profile the user was moved off in its metadata.
+// With reactivateClosedUsers set, a closed user is reactivated before being moved, and the grant metadata records the reactivation.
+func (p *permissionProfileBuilder) Grant(ctx context.Context, principal *v2.Resource, ent *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	if principal.Id.ResourceType != userResourceType.Id {
+		return nil, nil, fmt.Errorf("docusign-connector: only users can be assigned a permission profile")
+	}
+
+	userID := principal.Id.Resource
+	profileID := ent.Resource.Id.Resource
+
+	detail, annos, err := p.client.GetUserDetails(ctx, userID)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to get user %s: %w", userID, err)
+	}
+
+	var reactivation map[string]interface{}
+	if p.reactivateClosedUsers {
+		var reactivateAnnos annotations.Annotations
+		reactivation, reactivateAnnos, err = reactivateClosedUser(ctx, p.client, detail)
+		annos = append(annos, reactivateAnnos...)
+		if err != nil {
+			return nil, annos, err
+		}
+	}
+
+	metadata := map[string]interface{}{
+		"permission_profile_id":   profileID,
+		"permission_profile_name": ent.Resource.DisplayName,
+		"user_id":                 userID,
+		"username":                detail.UserName,
+	}
+	maps.Copy(metadata, reactivation)
+
+	if detail.PermissionProfileId == profileID {
+		// A reactivated user who already had the profile still gets a grant, since the reactivation is what restores the access.
+		if reactivation == nil {
+			annos.Update(&v2.GrantAlreadyExists{})
+			return nil, annos, nil
+		}
+	} else {
+		previous, assignAnnos, err := p.assignPermissionProfile(ctx, detail, profileID)
+		annos = append(annos, assignAnnos...)
+		if err != nil {
+			return nil, annos, err
+		}
+		metadata["previous_permission_profile_id"] = previous.PermissionProfileId
+		metadata["previous_permission_profile_name"] = previous.PermissionProfileName
+	}
+
+	g := grant.NewGrant(ent.Resource, entitlementPermissionProfileAssigned, principal.Id, grant.WithGrantMetadata(metadata))
+
+	return []*v2.Grant{g}, annos, nil
+}
+
+// Revoke moves the user to the configured fallback permission profile.
+// Group grants come from the group's settings in DocuSign and cannot be revoked.
+func (p *permissionProfileBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	if g.Principal.Id.ResourceType != userResourceType.Id {
+		return nil, fmt.Errorf("docusign-connector: only user permission profile assignments can be revoked")
+	}
+
+	userID := g.Principal.Id.Resource
+	profileID := g.Entitlement.Resource.Id.Resource
+
+	if p.fallbackProfileID == "" {
+		return nil, fmt.Errorf("docusign-connector: every user needs a permission profile, configure a fallback permission profile to revoke assignments")
+	}
+	if profileID == p.fallbackProfileID {
+		return nil, fmt.Errorf("docusign-connector: cannot revoke the fallback permission profile %s", profileID)
+	}
+
+	detail, annos, err := p.client.GetUserDetails(ctx, userID)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to get user %s: %w", userID, err)
+	}
+
+	if detail.PermissionProfileId != profileID {
+		annos.Update(&v2.GrantAlreadyRevoked{})
+		return annos, nil
+	}
+
+	_, assignAnnos, err := p.assignPermissionProfile(ctx, detail, p.fallbackProfileID)
+	annos = append(annos, assignAnnos...)
+	if err != nil {
+		return annos, err
+	}
+
+	return annos, nil
+}
+
+// Create creates a custom permission profile named after the resource, using the "settings" map of its role profile.
+func (p *permissionProfileBuilder) Create(ctx context.Context, profileResource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	name := profileResource.DisplayName
+	if name == "" {
+		return nil, nil, fmt.Errorf("docusign-connector: a permission profile name is required")
+	}
+	if isBuiltInPermissionProfile(name) {
+		return nil, nil, fmt.Errorf("docusign-connector: %s is a built-in permission profile", name)
+	}
+
+	request := client.PermissionProfileCreateRequest{PermissionProfileName: name}
+	if roleTrait, err := resource.GetRoleTrait(profileResource); err == nil {
+		if settings, ok := roleTrait.GetProfile().AsMap()["settings"].(map[string]interface{}); ok {
+			request.Settings = settings
+		}
+	}
+
+	profile, annos, err := p.client.CreatePermissionProfile(ctx, request)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to create permission profile %s: %w", name, err)
+	}
+
+	created, err := parseIntoPermissionProfileResource(profile)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return created, annos, nil
+}
+
+// Delete removes a custom permission profile. Built-in profiles and profiles still assigned to users are refused.
+func (p *permissionProfileBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	profileID := resourceId.Resource
+
+	profiles, annos, err := p.client.GetPermissionProfiles(ctx)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to list permission profiles: %w", err)
+	}
+
+	var profile *client.PermissionProfile
+	for i := range profiles {
+		if profiles[i].PermissionProfileId == profileID {
+			profile = &profiles[i]
+			break
+		}
+	}
+	if profile == nil {
+		return annos, fmt.Errorf("docusign-connector: permission profile %s not found", profileID)
+	}
+	if isBuiltInPermissionProfile(profile.PermissionProfileName) {
+		return annos, fmt.Errorf("docusign-connector: cannot delete the built-in permission profile %s", profile.PermissionProfileName)
+	}
+
+	assigned, usersAnnos, err := p.client.HasPermissionProfileUsers(ctx, profileID)
+	annos = append(annos, usersAnnos...)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to check users of permission profile %s: %w", profileID, err)
+	}
+	if assigned {
+		return annos, fmt.Errorf("docusign-connector: cannot delete permission profile %s while users are still assigned to it, move them to another profile first", profile.PermissionProfileName)
+	}
+
+	deleteAnnos, err := p.client.DeletePermissionProfile(ctx, profileID)
+	annos = append(annos, deleteAnnos...)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to delete permission profile %s: %w", profileID, err)
+	}
+
+	return annos, nil
+}
+
+// assignPermissionProfile moves the user to the target profile and returns the profile the user was moved off.
+// Demoting the last account administrator to a non-admin profile is refused.
+func (p *permissionProfileBuilder) assignPermissionProfile(
+	ctx context.Context,
+	user *client.UserDetail,
+	targetProfileID string,
+) (*client.PermissionProfile, annotations.Annotations, error) {
+	profiles, annos, err := p.client.GetPermissionProfiles(ctx)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to list permission profiles: %w", err)
+	}
+
+	var target *client.PermissionProfile
+	previous := &client.PermissionProfile{
+		PermissionProfileId:   user.PermissionProfileId,
+		PermissionProfileName: user.PermissionProfileName,
+	}
+	for i := range profiles {
+		switch profiles[i].PermissionProfileId {
+		case targetProfileID:
+			target = &profiles[i]
+		case user.PermissionProfileId:
+			previous = &profiles[i]
+		}
+	}
+	if target == nil {
+		return nil, annos, fmt.Errorf("docusign-connector: permission profile %s not found", targetProfileID)
+	}
+
+	if isUserAdmin(user) && !isAdminPermissionProfile(target) {
+		guardAnnos, err := ensureNotLastAdmin(ctx, p.client, user.UserID)
+		annos = append(annos, guardAnnos...)
+		if err != nil {
+			return nil, annos, err
+		}
+	}
+
+	updateAnnos, err := p.client.UpdateUser(ctx, user.UserID, client.UserUpdate{PermissionProfileId: targetProfileID})
+	annos = append(annos, updateAnnos...)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to assign permission profile %s to %s: %w", targetProfileID, user.UserID, err)
+	}
+
+	return previous, annos, nil
+}
+
+// newPermissionProfileBuilder constructs a permissionProfileBuilder with the provided API client.
+// fallbackProfileID is the profile users are moved to when an assignment is revoked.
+// permissions maps profile settings to permission grants, nil uses the built-in catalog in singleton mode.
+func newPermissionProfileBuilder(client PermissionProfileClient, fallbackProfileID string, permissions *permissionBuilder) *permissionProfileBuilder {
+	if permissions == nil {
+		permissions = newPermissionBuilder(nil, nil, false)
+	}
+	return &permissionProfileBuilder{
+		resourceType:      permissionProfileResourceType,
+		client:            client,
+		fallbackProfileID: fallbackProfileID,
+		permissions:       permissions,
+	}
+}
+
+// withClosedUserReactivation makes Grant reactivate closed users before moving them to a profile.
+func (p *permissionProfileBuilder) withClosedUserReactivation() *permissionProfileBuilder {
+	p.reactivateClosedUsers = true
+	return p
+}
+
+// createPermissionProfileExpansionGrants grants the profile each permission enabled by its settings.
+// Users holding the profile's "assigned" entitlement are expanded into the permission grants.
+func createPermissionProfileExpansionGrants(permissions *permissionBuilder, profileResource *v2.Resource, settings map[string]interface{}) []*v2.Grant {
+	assignedEntitlementID := entitlement.NewEntitlementID(profileResource, entitlementPermissionProfileAssigned)
+	slugs, accessLevels := catalogOrDefault(permissions.catalog).Granted(settings)
+
+	grants := make([]*v2.Grant, 0, len(slugs))
+	for _, slug := range slugs {
+		grants = append(grants, permissions.newPermissionGrant(
+			slug,
+			profileResource.Id,
+			map[string]interface{}{
+				"origin":                  permissionOriginProfile,
+				"permission_profile_id":   profileResource.Id.Resource,
+				"permission_profile_name": profileResource.DisplayName,
+				"access_level":            accessLevels[slug],
+			},
+			grant.WithAnnotation(&v2.GrantExpandable{
+				EntitlementIds: []string{assignedEntitlementID},
+			}),
+		))
+	}
+	return grants
+}
+
+// isBuiltInPermissionProfile reports whether the profile is one DocuSign creates in every account.
+func isBuiltInPermissionProfile(name string) bool {
+	for _, builtIn := range builtInPermissionProfiles {
+		if strings.EqualFold(builtIn, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIntoPermissionProfileResource maps a client.PermissionProfile to a Baton role resource.
+func parseIntoPermissionProfileResource(profile *client.PermissionProfile) (*v2.Resource, error) {
+	roleProfile := map[string]interface{}{
+		"permission_profile_id":   profile.PermissionProfileId,
+		"permission_profile_name": profile.PermissionProfileName,
+		"modified_date_time":      profile.ModifiedDateTime,
+	}
+	if profile.Settings != nil {
+		roleProfile["settings"] = profile.Settings
+	}
+
+	return resource.NewRoleResource(
+		profile.PermissionProfileName,
+		permissionProfileResourceType,
+		profile.PermissionProfileId,
+		[]resource.RoleTraitOption{
+			resource.WithRoleProfile(roleProfile),
+		},
+	)
+}
+
+// createPermissionProfileGrant generates the "assigned" grant for the user's permission profile.
+// It returns nil when the user has no permission profile.
+func createPermissionProfileGrant(user *client.UserDetail) *v2.Grant {
+	if user.PermissionProfileId == "" {
+		return nil
+	}
+
+	profileResource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: permissionProfileResourceType.Id,
+			Resource:     user.PermissionProfileId,
+		},
+	}
+	return grant.NewGrant(
+		profileResource,
+		entitlementPermissionProfileAssigned,
+		makeUserSubjectID(user.UserID),
+		grant.WithGrantMetadata(map[string]interface{}{
+			"permission_profile_id":   user.PermissionProfileId,
+			"permission_profile_name": user.PermissionProfileName,
+			"user_id":                 user.UserID,
+			"username":                user.UserName,
+		}),
+	)
+}
+
+// createGroupPermissionProfileGrant grants the group the "assigned" entitlement of its permission profile.
+// The grant expands to the group members, whose inherited access records the source group in its metadata.
+func createGroupPermissionProfileGrant(groupResource *v2.Resource, profileID string) *v2.Grant {
+	profileResource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: permissionProfileResourceType.Id,
+			Resource:     profileID,
+		},
+	}
+	return grant.NewGrant(
+		profileResource,
+		entitlementPermissionProfileAssigned,
+		groupResource.Id,
+		grant.WithAnnotation(&v2.GrantExpandable{
+			EntitlementIds: []string{entitlement.NewEntitlementID(groupResource, entitlementGroupMember)},
+		}),
+		grant.WithGrantMetadata(map[string]interface{}{
+			"origin":                permissionOriginGroup,
+			"permission_profile_id": profileID,
+			"source_group_id":       groupResource.Id.Resource,
+			"source_group_name":     groupResource.DisplayName,
+		}),
+	)
+}
@@ -0,0 +1,392 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPermissionProfileClient implements PermissionProfileClient with a fixed list of profiles and users.
+type mockPermissionProfileClient struct {
+	profiles []client.PermissionProfile
+	users    []client.User
+	details  map[string]*client.UserDetail
+	updates  map[string]string
+	assigned map[string]bool
+	created  []client.PermissionProfileCreateRequest
+	deleted  []string
+}
+
+func (m *mockPermissionProfileClient) GetPermissionProfiles(ctx context.Context) ([]client.PermissionProfile, annotations.Annotations, error) {
+	return m.profiles, nil, nil
+}
+
+func (m *mockPermissionProfileClient) GetUsers(ctx context.Context, options client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+	return m.users, "", nil, nil
+}
+
+func (m *mockPermissionProfileClient) GetUserDetails(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+	detail, ok := m.details[userID]
+	if !ok {
+		return nil, nil, fmt.Errorf("user %s not found", userID)
+	}
+	return detail, nil, nil
+}
+
+func (m *mockPermissionProfileClient) UpdateUser(ctx context.Context, userID string, update client.UserUpdate) (annotations.Annotations, error) {
+	if m.updates == nil {
+		m.updates = map[string]string{}
+	}
+	m.updates[userID] = update.PermissionProfileId
+	return nil, nil
+}
+
+func (m *mockPermissionProfileClient) CreatePermissionProfile(ctx context.Context, request client.PermissionProfileCreateRequest) (*client.PermissionProfile, annotations.Annotations, error) {
+	m.created = append(m.created, request)
+	return &client.PermissionProfile{
+		PermissionProfileId:   "2001",
+		PermissionProfileName: request.PermissionProfileName,
+		Settings:              request.Settings,
+	}, nil, nil
+}
+
+func (m *mockPermissionProfileClient) DeletePermissionProfile(ctx context.Context, profileID string) (annotations.Annotations, error) {
+	m.deleted = append(m.deleted, profileID)
+	return nil, nil
+}
+
+func (m *mockPermissionProfileClient) HasPermissionProfileUsers(ctx context.Context, profileID string) (bool, annotations.Annotations, error) {
+	return m.assigned[profileID], nil, nil
+}
+
+func readMockPermissionProfiles(t *testing.T) []client.PermissionProfile {
+	var parsed client.PermissionProfilesResponse
+	require.NoError(t, json.NewDecoder(bytes.NewReader([]byte(ReadMockResponse("permission_profiles.json")))).Decode(&parsed))
+	return parsed.PermissionProfiles
+}
+
+// TestPermissionProfileBuilder_List verifies profiles are synced as roles carrying their settings.
+func TestPermissionProfileBuilder_List(t *testing.T) {
+	builder := newPermissionProfileBuilder(&mockPermissionProfileClient{profiles: readMockPermissionProfiles(t)}, "", nil)
+
+	resources, nextToken, _, err := builder.List(context.Background(), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Empty(t, nextToken)
+
+	assert.Equal(t, "DS Admin", resources[0].DisplayName)
+	assert.Equal(t, "1001", resources[0].Id.Resource)
+	assert.Equal(t, permissionProfileResourceType.Id, resources[0].Id.ResourceType)
+
+	roleTrait, err := resource.GetRoleTrait(resources[0])
+	require.NoError(t, err)
+	settings, ok := roleTrait.GetProfile().AsMap()["settings"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "admin", settings["powerFormMode"])
+
+	ents, _, _, err := builder.Entitlements(context.Background(), resources[0], nil)
+	require.NoError(t, err)
+	require.Len(t, ents, 1)
+	assert.Equal(t, entitlementPermissionProfileAssigned, ents[0].Slug)
+}
+
+// TestUserBuilder_Grants_PermissionProfile verifies users are granted their permission profile.
+func TestUserBuilder_Grants_PermissionProfile(t *testing.T) {
+	mockClient := &mockClient{
+		getUserDetailsFunc: func(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+			return &client.UserDetail{
+				UserID:                userID,
+				PermissionProfileId:   "1002",
+				PermissionProfileName: "DocuSign Sender",
+			}, nil, nil
+		},
+	}
+	builder := &userBuilder{resourceType: userResourceType, client: mockClient, permissionBuilder: newPermissionBuilder(nil, nil, false)}
+
+	userRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}
+	grants, _, _, err := builder.Grants(context.Background(), userRes, nil)
+	require.NoError(t, err)
+
+	var profileGrants []*v2.Grant
+	for _, g := range grants {
+		if g.Entitlement.Resource.Id.ResourceType == permissionProfileResourceType.Id {
+			profileGrants = append(profileGrants, g)
+		}
+	}
+	require.Len(t, profileGrants, 1)
+	assert.Equal(t, "1002", profileGrants[0].Entitlement.Resource.Id.Resource)
+	assert.Equal(t, "u1", profileGrants[0].Principal.Id.Resource)
+}
+
+// TestPermissionProfileBuilder_Grant verifies users are moved to the granted profile.
+func TestPermissionProfileBuilder_Grant(t *testing.T) {
+	profileClient := &mockPermissionProfileClient{
+		profiles: readMockPermissionProfiles(t),
+		details: map[string]*client.UserDetail{
+			"u1": {UserID: "u1", UserName: "Jane", IsAdmin: "False", PermissionProfileId: "1002", PermissionProfileName: "DocuSign Sender"},
+		},
+	}
+	builder := newPermissionProfileBuilder(profileClient, "1002", nil)
+	principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}
+
+	t.Run("assigns the profile and reports the previous one", func(t *testing.T) {
+		ent := newTestPermissionProfileEntitlement(t, "1001", "DS Admin")
+
+		grants, _, err := builder.Grant(context.Background(), principal, ent)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, "1001", profileClient.updates["u1"])
+
+		metadata := &v2.GrantMetadata{}
+		grantAnnos := annotations.Annotations(grants[0].Annotations)
+		ok, err := grantAnnos.Pick(metadata)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "1002", metadata.Metadata.AsMap()["previous_permission_profile_id"])
+		assert.Equal(t, "DocuSign Sender", metadata.Metadata.AsMap()["previous_permission_profile_name"])
+	})
+
+	t.Run("reports existing assignments", func(t *testing.T) {
+		profileClient.updates = nil
+		ent := newTestPermissionProfileEntitlement(t, "1002", "DocuSign Sender")
+
+		grants, annos, err := builder.Grant(context.Background(), principal, ent)
+		require.NoError(t, err)
+		assert.Empty(t, grants)
+		assert.True(t, annos.Contains(&v2.GrantAlreadyExists{}))
+		assert.Empty(t, profileClient.updates)
+	})
+}
+
+// TestPermissionProfileBuilder_Revoke verifies users are moved to the fallback profile and the last admin is protected.
+func TestPermissionProfileBuilder_Revoke(t *testing.T) {
+	newRevokeGrant := func(t *testing.T, userID, profileID string) *v2.Grant {
+		ent := newTestPermissionProfileEntitlement(t, profileID, "")
+		return &v2.Grant{
+			Entitlement: ent,
+			Principal:   &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: userID}},
+		}
+	}
+
+	t.Run("moves the user to the fallback profile", func(t *testing.T) {
+		profileClient := &mockPermissionProfileClient{
+			profiles: readMockPermissionProfiles(t),
+			users: []client.User{
+				{UserId: "admin1", IsAdmin: "True", UserStatus: "Active"},
+				{UserId: "admin2", IsAdmin: "True", UserStatus: "Active"},
+			},
+			details: map[string]*client.UserDetail{
+				"admin1": {UserID: "admin1", IsAdmin: "True", PermissionProfileId: "1001"},
+			},
+		}
+		builder := newPermissionProfileBuilder(profileClient, "1002", nil)
+
+		_, err := builder.Revoke(context.Background(), newRevokeGrant(t, "admin1", "1001"))
+		require.NoError(t, err)
+		assert.Equal(t, "1002", profileClient.updates["admin1"])
+	})
+
+	t.Run("refuses to demote the last administrator", func(t *testing.T) {
+		profileClient := &mockPermissionProfileClient{
+			profiles: readMockPermissionProfiles(t),
+			users: []client.User{
+				{UserId: "admin1", IsAdmin: "True", UserStatus: "Active"},
+				{UserId: "admin2", IsAdmin: "True", UserStatus: "Closed"},
+			},
+			details: map[string]*client.UserDetail{
+				"admin1": {UserID: "admin1", IsAdmin: "True", PermissionProfileId: "1001"},
+			},
+		}
+		builder := newPermissionProfileBuilder(profileClient, "1002", nil)
+
+		_, err := builder.Revoke(context.Background(), newRevokeGrant(t, "admin1", "1001"))
+		require.Error(t, err)
+		assert.Empty(t, profileClient.updates)
+	})
+
+	t.Run("reports already revoked assignments", func(t *testing.T) {
+		profileClient := &mockPermissionProfileClient{
+			profiles: readMockPermissionProfiles(t),
+			details: map[string]*client.UserDetail{
+				"u1": {UserID: "u1", PermissionProfileId: "1002"},
+			},
+		}
+		builder := newPermissionProfileBuilder(profileClient, "1002", nil)
+
+		annos, err := builder.Revoke(context.Background(), newRevokeGrant(t, "u1", "1001"))
+		require.NoError(t, err)
+		assert.True(t, annos.Contains(&v2.GrantAlreadyRevoked{}))
+	})
+
+	t.Run("requires a fallback profile", func(t *testing.T) {
+		builder := newPermissionProfileBuilder(&mockPermissionProfileClient{}, "", nil)
+
+		_, err := builder.Revoke(context.Background(), newRevokeGrant(t, "u1", "1001"))
+		require.Error(t, err)
+	})
+
+	t.Run("refuses group grants", func(t *testing.T) {
+		builder := newPermissionProfileBuilder(&mockPermissionProfileClient{}, "1002", nil)
+
+		groupGrant := newRevokeGrant(t, "g1", "1001")
+		groupGrant.Principal.Id.ResourceType = groupResourceType.Id
+		_, err := builder.Revoke(context.Background(), groupGrant)
+		require.Error(t, err)
+	})
+}
+
+func newTestPermissionProfileEntitlement(t *testing.T, profileID, name string) *v2.Entitlement {
+	profileResource, err := parseIntoPermissionProfileResource(&client.PermissionProfile{
+		PermissionProfileId:   profileID,
+		PermissionProfileName: name,
+	})
+	require.NoError(t, err)
+
+	builder := newPermissionProfileBuilder(&mockPermissionProfileClient{}, "", nil)
+	ents, _, _, err := builder.Entitlements(context.Background(), profileResource, nil)
+	require.NoError(t, err)
+	return ents[0]
+}
+
+// TestPermissionProfileBuilder_Create verifies custom profiles are created from the resource name and settings.
+func TestPermissionProfileBuilder_Create(t *testing.T) {
+	profileClient := &mockPermissionProfileClient{}
+	builder := newPermissionProfileBuilder(profileClient, "", nil)
+
+	profileResource, err := parseIntoPermissionProfileResource(&client.PermissionProfile{
+		PermissionProfileName: "Contract Managers",
+		Settings:              map[string]interface{}{"canSendEnvelope": "true"},
+	})
+	require.NoError(t, err)
+
+	created, _, err := builder.Create(context.Background(), profileResource)
+	require.NoError(t, err)
+	assert.Equal(t, "2001", created.Id.Resource)
+	require.Len(t, profileClient.created, 1)
+	assert.Equal(t, "Contract Managers", profileClient.created[0].PermissionProfileName)
+	assert.Equal(t, "true", profileClient.created[0].Settings["canSendEnvelope"])
+
+	builtIn, err := parseIntoPermissionProfileResource(&client.PermissionProfile{PermissionProfileName: "DS Admin"})
+	require.NoError(t, err)
+	_, _, err = builder.Create(context.Background(), builtIn)
+	require.Error(t, err)
+}
+
+// TestPermissionProfileBuilder_Delete verifies built-in and assigned profiles are protected from deletion.
+func TestPermissionProfileBuilder_Delete(t *testing.T) {
+	profiles := append(readMockPermissionProfiles(t),
+		client.PermissionProfile{PermissionProfileId: "2001", PermissionProfileName: "Contract Managers"},
+		client.PermissionProfile{PermissionProfileId: "2002", PermissionProfileName: "Legal"},
+	)
+	profileClient := &mockPermissionProfileClient{
+		profiles: profiles,
+		assigned: map[string]bool{"2002": true},
+	}
+	builder := newPermissionProfileBuilder(profileClient, "", nil)
+
+	tests := []struct {
+		name      string
+		profileID string
+		wantErr   bool
+	}{
+		{name: "custom profile without users", profileID: "2001"},
+		{name: "custom profile with users", profileID: "2002", wantErr: true},
+		{name: "built-in profile", profileID: "1001", wantErr: true},
+		{name: "unknown profile", profileID: "9999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileClient.deleted = nil
+			_, err := builder.Delete(context.Background(), &v2.ResourceId{ResourceType: permissionProfileResourceType.Id, Resource: tt.profileID})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Empty(t, profileClient.deleted)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.profileID}, profileClient.deleted)
+		})
+	}
+}
+
+// TestPermissionProfileBuilder_Grants verifies profiles are granted the permissions of their settings, expandable to assigned users.
+// The settings come from the synced resource, so the client has no profiles to return.
+func TestPermissionProfileBuilder_Grants(t *testing.T) {
+	builder := newPermissionProfileBuilder(&mockPermissionProfileClient{}, "", nil)
+	profileResource, err := parseIntoPermissionProfileResource(&readMockPermissionProfiles(t)[0])
+	require.NoError(t, err)
+
+	grants, _, _, err := builder.Grants(context.Background(), profileResource, nil)
+	require.NoError(t, err)
+
+	var permissionIDs []string
+	for _, g := range grants {
+		permissionIDs = append(permissionIDs, g.Entitlement.Id)
+		assert.Equal(t, permissionResourceID, g.Entitlement.Resource.Id.Resource)
+		assert.Equal(t, profileResource.Id.Resource, g.Principal.Id.Resource)
+
+		expandable := &v2.GrantExpandable{}
+		grantAnnos := annotations.Annotations(g.Annotations)
+		ok, err := grantAnnos.Pick(expandable)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, []string{"permission_profile:1001:assigned"}, expandable.EntitlementIds)
+	}
+	assert.ElementsMatch(t, []string{
+		"permission:docusign-permissions:canManageAccount",
+		"permission:docusign-permissions:canSendEnvelope",
+		"permission:docusign-permissions:powerFormMode",
+	}, permissionIDs)
+}
+
+// TestUserBuilder_Grants_PermissionOrigin verifies user permission grants record whether they come from the profile or a user override.
+func TestUserBuilder_Grants_PermissionOrigin(t *testing.T) {
+	mockClient := &mockClient{
+		getUserDetailsFunc: func(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+			return &client.UserDetail{
+				UserID:              userID,
+				PermissionProfileId: "1002",
+				UserSettings: client.UserSettings{
+					CanSendEnvelope: "true",
+					BulkSend:        "true",
+				},
+			}, nil, nil
+		},
+		getPermissionProfilesFunc: func(ctx context.Context) ([]client.PermissionProfile, annotations.Annotations, error) {
+			return readMockPermissionProfiles(t), nil, nil
+		},
+	}
+	builder := &userBuilder{resourceType: userResourceType, client: mockClient, permissionBuilder: newPermissionBuilder(nil, nil, false)}
+
+	userRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}
+	grants, _, _, err := builder.Grants(context.Background(), userRes, nil)
+	require.NoError(t, err)
+
+	origins := map[string]interface{}{}
+	for _, g := range grants {
+		if g.Entitlement.Resource.Id.ResourceType != permissionResourceType.Id {
+			continue
+		}
+		metadata := &v2.GrantMetadata{}
+		grantAnnos := annotations.Annotations(g.Annotations)
+		ok, err := grantAnnos.Pick(metadata)
+		require.NoError(t, err)
+		require.True(t, ok)
+		origins[g.Entitlement.Id] = metadata.Metadata.AsMap()["origin"]
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"permission:docusign-permissions:canSendEnvelope": permissionOriginProfile,
+		"permission:docusign-permissions:bulkSend":        permissionOriginOverride,
+	}, origins)
+}
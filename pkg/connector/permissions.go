@@ -4,19 +4,41 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"strings"
 
-	"github.com/conductorone/baton-docusign/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	"github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
 	"github.com/conductorone/baton-sdk/pkg/types/resource"
 )
 
+// PermissionClient defines the methods required to grant and revoke permissions through user settings.
+type PermissionClient interface {
+	GetUserSettings(ctx context.Context, userID string) (map[string]interface{}, annotations.Annotations, error)
+	UpdateUserSettings(ctx context.Context, userID string, settings map[string]interface{}) (annotations.Annotations, error)
+}
+
+// Permission resource modes, selecting how the catalog permissions are synced.
+const (
+	// permissionModeSingleton syncs every permission as an entitlement of the docusign-permissions resource.
+	permissionModeSingleton = "singleton"
+	// permissionModePerPermission syncs each catalog permission as its own role resource.
+	permissionModePerPermission = "per-permission"
+)
+
+// entitlementPermissionHas is the entitlement of a per-permission resource whose permission has no access levels.
+// Leveled permissions get one entitlement per level, named after the level suffix.
+const entitlementPermissionHas = "has"
+
 // permissionBuilder handles the construction of permission-related resources and grants.
 type permissionBuilder struct {
-	resourceType *v2.ResourceType
-	client       *client.Client
+	resourceType  *v2.ResourceType
+	client        PermissionClient
+	catalog       *permissionCatalog
+	perPermission bool
 }
 
 // ResourceType returns the resource type this builder manages (docusign-permissions).
@@ -24,20 +46,29 @@ func (p *permissionBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
 	return permissionResourceType
 }
 
-// List returns the singleton permission resource that represents all DocuSign permissions.
+// List returns the singleton permission resource that represents all DocuSign permissions,
+// or one role resource per catalog permission in per-permission mode.
 func (p *permissionBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
 	annos := annotations.Annotations{}
-	permissionResource, err := resource.NewRoleResource(
-		permissionResourceID,
-		permissionResourceType,
-		permissionResourceID,
-		nil,
-	)
-	if err != nil {
-		return nil, "", nil, fmt.Errorf("failed to create permission resource: %w", err)
+	if !p.perPermission {
+		permissionResource, err := p.GetPermissionResource(ctx)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		return []*v2.Resource{permissionResource}, "", annos, nil
 	}
 
-	return []*v2.Resource{permissionResource}, "", annos, nil
+	definitions := catalogOrDefault(p.catalog).Definitions()
+	resources := make([]*v2.Resource, 0, len(definitions))
+	for _, definition := range definitions {
+		permissionResource, err := parseIntoPermissionResource(definition)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to create permission resource %s: %w", definition.ID, err)
+		}
+		resources = append(resources, permissionResource)
+	}
+
+	return resources, "", annos, nil
 }
 
 // GetPermissionResource returns the singleton permission resource.
@@ -54,30 +85,276 @@ func (p *permissionBuilder) GetPermissionResource(ctx context.Context) (*v2.Reso
 	return permissionResource, nil
 }
 
-// Entitlements generates all possible permission entitlements for the permission resource.
+// Entitlements generates the permission entitlements of a permission resource.
+// The SDK has neither a metadata field nor a typed annotation for the sensitivity and compliance tags of an entitlement,
+// so they are not attached to the entitlements. They are recorded in the grant metadata and the role profile instead.
+// The singleton resource gets every catalog permission, with one entitlement per access level for leveled permissions.
+// A per-permission resource gets a "has" entitlement, or one entitlement per access level.
 func (p *permissionBuilder) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
-	entitlements := make([]*v2.Entitlement, 0, len(permissionDefinitions))
+	permissions := catalogOrDefault(p.catalog).Entitlements()
+	entitlements := make([]*v2.Entitlement, 0, len(permissions))
 	annos := annotations.Annotations{}
-	for _, permission := range permissionDefinitions {
+	for _, permission := range permissions {
+		slug := permission.Slug
+		if resource.Id.Resource != permissionResourceID {
+			if permission.PermissionID != resource.Id.Resource {
+				continue
+			}
+			slug = perPermissionEntitlementSlug(permission.Suffix)
+		}
+
 		entitlements = append(entitlements, entitlement.NewPermissionEntitlement(
 			resource,
-			permission.ID,
+			slug,
 			entitlement.WithDisplayName(permission.DisplayName),
 			entitlement.WithDescription(permission.Description),
-			entitlement.WithGrantableTo(userResourceType),
+			entitlement.WithGrantableTo(userResourceType, permissionProfileResourceType, permissionResourceType),
 		))
 	}
 
 	return entitlements, "", annos, nil
 }
 
-// Grants would assign permissions to users. This is intentionally left empty as grants are now handled by the userBuilder.
+// Grants links each access level to the level directly above it, so holders of a higher level are expanded
+// into the lower ones. User grants are handled by the userBuilder.
 func (p *permissionBuilder) Grants(
 	ctx context.Context,
 	permissionResource *v2.Resource,
 	pageToken *pagination.Token,
 ) ([]*v2.Grant, string, annotations.Annotations, error) {
-	return nil, "", nil, nil
+	var grants []*v2.Grant
+	for _, implication := range catalogOrDefault(p.catalog).LevelImplications() {
+		lowerResource, lowerSlug := p.permissionTarget(implication[0])
+		if lowerResource.Id.Resource != permissionResource.Id.Resource {
+			continue
+		}
+		_, higherSlug := p.permissionTarget(implication[1])
+
+		grants = append(grants, grant.NewGrant(
+			permissionResource,
+			lowerSlug,
+			permissionResource.Id,
+			grant.WithAnnotation(&v2.GrantExpandable{
+				EntitlementIds: []string{entitlement.NewEntitlementID(permissionResource, higherSlug)},
+			}),
+		))
+	}
+
+	return grants, "", nil, nil
+}
+
+// Grant sets the user setting mapped to the permission, then re-reads the user's settings to confirm the effective value.
+func (p *permissionBuilder) Grant(ctx context.Context, principal *v2.Resource, ent *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	if principal.Id.ResourceType != userResourceType.Id {
+		return nil, nil, fmt.Errorf("docusign-connector: only users can be granted a permission")
+	}
+
+	catalog := catalogOrDefault(p.catalog)
+	userID := principal.Id.Resource
+	slug := permissionCatalogSlug(catalog, ent)
+
+	change, err := catalog.GrantChange(slug)
+	if err != nil {
+		return nil, nil, fmt.Errorf("docusign-connector: %w", err)
+	}
+
+	settings, annos, err := p.client.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to get settings of %s: %w", userID, err)
+	}
+	if catalog.Holds(settings, slug) {
+		annos.Update(&v2.GrantAlreadyExists{})
+		return nil, annos, nil
+	}
+
+	settings, updateAnnos, err := p.updateSetting(ctx, userID, change)
+	annos = append(annos, updateAnnos...)
+	if err != nil {
+		return nil, annos, err
+	}
+	if !catalog.Holds(settings, slug) {
+		return nil, annos, fmt.Errorf("docusign-connector: %s was updated but does not hold %s, DocuSign may restrict it on this account", userID, slug)
+	}
+
+	_, accessLevels := catalog.Granted(settings)
+	metadata := map[string]interface{}{
+		"source":       "DocuSign",
+		"user_id":      userID,
+		"access_level": accessLevels[slug],
+	}
+	if permission, ok := catalog.Entitlement(slug); ok {
+		maps.Copy(metadata, permissionRiskMetadata(permission))
+	}
+	g := grant.NewGrant(
+		ent.Resource,
+		permissionEntitlementSlug(ent),
+		principal.Id,
+		grant.WithGrantMetadata(metadata),
+	)
+
+	return []*v2.Grant{g}, annos, nil
+}
+
+// Revoke clears the user setting mapped to the permission, lowering leveled settings to the level below,
+// then re-reads the user's settings to confirm the permission is gone.
+func (p *permissionBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	if g.Principal.Id.ResourceType != userResourceType.Id {
+		return nil, fmt.Errorf("docusign-connector: only user permissions can be revoked")
+	}
+
+	catalog := catalogOrDefault(p.catalog)
+	userID := g.Principal.Id.Resource
+	slug := permissionCatalogSlug(catalog, g.Entitlement)
+
+	change, err := catalog.RevokeChange(slug)
+	if err != nil {
+		return nil, fmt.Errorf("docusign-connector: %w", err)
+	}
+
+	settings, annos, err := p.client.GetUserSettings(ctx, userID)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to get settings of %s: %w", userID, err)
+	}
+	if !catalog.Holds(settings, slug) {
+		annos.Update(&v2.GrantAlreadyRevoked{})
+		return annos, nil
+	}
+
+	settings, updateAnnos, err := p.updateSetting(ctx, userID, change)
+	annos = append(annos, updateAnnos...)
+	if err != nil {
+		return annos, err
+	}
+	if catalog.Holds(settings, slug) {
+		return annos, fmt.Errorf("docusign-connector: %s was updated but still holds %s, it may come from the user's permission profile", userID, slug)
+	}
+
+	return annos, nil
+}
+
+// updateSetting writes a single user setting and returns the settings read back afterwards.
+func (p *permissionBuilder) updateSetting(ctx context.Context, userID string, change settingChange) (map[string]interface{}, annotations.Annotations, error) {
+	annos, err := p.client.UpdateUserSettings(ctx, userID, nestedSetting(change.Path, change.Value))
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to set %s of %s: %w", change.Path, userID, err)
+	}
+
+	settings, readAnnos, err := p.client.GetUserSettings(ctx, userID)
+	annos = append(annos, readAnnos...)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to re-read settings of %s: %w", userID, err)
+	}
+
+	return settings, annos, nil
+}
+
+// permissionTarget returns the resource and entitlement slug a catalog permission is synced as in the current mode.
+func (p *permissionBuilder) permissionTarget(slug string) (*v2.Resource, string) {
+	if p.perPermission {
+		if definition, level, ok := catalogOrDefault(p.catalog).lookupEntitlement(slug); ok {
+			suffix := ""
+			if level >= 0 {
+				suffix = definition.Levels[level].Suffix
+			}
+			return permissionResourceRef(definition.ID), perPermissionEntitlementSlug(suffix)
+		}
+	}
+	return permissionResourceRef(permissionResourceID), slug
+}
+
+// newPermissionGrant grants a catalog permission to principal on the resource of the current mode.
+// The metadata is extended with the sensitivity and compliance tags of the permission.
+// In per-permission mode the metadata records the entitlement ID the grant has in singleton mode,
+// so existing grants can be matched to their new IDs.
+func (p *permissionBuilder) newPermissionGrant(slug string, principal *v2.ResourceId, metadata map[string]interface{}, opts ...grant.GrantOption) *v2.Grant {
+	permissionResource, entitlementSlug := p.permissionTarget(slug)
+	if permission, ok := catalogOrDefault(p.catalog).Entitlement(slug); ok {
+		maps.Copy(metadata, permissionRiskMetadata(permission))
+	}
+	if p.perPermission {
+		metadata["legacy_entitlement_id"] = entitlement.NewEntitlementID(permissionResourceRef(permissionResourceID), slug)
+	}
+	opts = append(opts, grant.WithGrantMetadata(metadata))
+	return grant.NewGrant(permissionResource, entitlementSlug, principal, opts...)
+}
+
+// permissionRiskMetadata returns the sensitivity and compliance tags of a permission entitlement.
+func permissionRiskMetadata(permission permissionEntitlement) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"sensitivity": permission.Sensitivity,
+	}
+	if len(permission.ComplianceTags) > 0 {
+		metadata["compliance_tags"] = strings.Join(permission.ComplianceTags, ",")
+	}
+	return metadata
+}
+
+// permissionResourceRef returns a reference to the permission resource with the given ID.
+func permissionResourceRef(resourceID string) *v2.Resource {
+	return &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: permissionResourceType.Id,
+			Resource:     resourceID,
+		},
+	}
+}
+
+// perPermissionEntitlementSlug returns the entitlement slug of a per-permission resource for an access level suffix.
+func perPermissionEntitlementSlug(suffix string) string {
+	if suffix == "" {
+		return entitlementPermissionHas
+	}
+	return suffix
+}
+
+// permissionCatalogSlug returns the catalog slug of a permission entitlement in either mode,
+// so grants synced before a mode change can still be provisioned.
+func permissionCatalogSlug(catalog *permissionCatalog, ent *v2.Entitlement) string {
+	slug := permissionEntitlementSlug(ent)
+	permissionID := ent.Resource.Id.Resource
+	switch {
+	case permissionID == permissionResourceID:
+		return slug
+	case slug == entitlementPermissionHas:
+		return permissionID
+	default:
+		return catalog.levelSlugForSuffix(permissionID, slug)
+	}
+}
+
+// permissionEntitlementSlug returns the slug of a permission entitlement, deriving it from the ID when unset.
+func permissionEntitlementSlug(ent *v2.Entitlement) string {
+	if ent.Slug != "" {
+		return ent.Slug
+	}
+	return strings.TrimPrefix(ent.Id, entitlement.NewEntitlementID(ent.Resource, ""))
+}
+
+// parseIntoPermissionResource maps a catalog permission to its own role resource.
+func parseIntoPermissionResource(definition permissionDefinition) (*v2.Resource, error) {
+	roleProfile := map[string]interface{}{
+		"permission_id": definition.ID,
+		"settings":      strings.Join(definition.Settings, ","),
+		"sensitivity":   definition.Sensitivity,
+	}
+	if len(definition.Levels) > 0 {
+		suffixes := make([]string, 0, len(definition.Levels))
+		for _, level := range definition.Levels {
+			suffixes = append(suffixes, level.Suffix)
+		}
+		roleProfile["levels"] = strings.Join(suffixes, ",")
+	}
+	if len(definition.ComplianceTags) > 0 {
+		roleProfile["compliance_tags"] = strings.Join(definition.ComplianceTags, ",")
+	}
+
+	return resource.NewRoleResource(
+		definition.DisplayName,
+		permissionResourceType,
+		definition.ID,
+		[]resource.RoleTraitOption{resource.WithRoleProfile(roleProfile)},
+		resource.WithDescription(definition.Description),
+	)
 }
 
 // makeUserSubjectID creates a ResourceId for a user based on their user ID.
@@ -104,9 +381,13 @@ func parseUserSettings(settings interface{}) (map[string]interface{}, error) {
 }
 
 // newPermissionBuilder creates a new permissionBuilder instance.
-func newPermissionBuilder(client *client.Client) *permissionBuilder {
+// catalog defines the permission entitlements, nil uses the built-in catalog.
+// perPermission syncs each permission as its own resource instead of the singleton docusign-permissions resource.
+func newPermissionBuilder(client PermissionClient, catalog *permissionCatalog, perPermission bool) *permissionBuilder {
 	return &permissionBuilder{
-		resourceType: permissionResourceType,
-		client:       client,
+		resourceType:  permissionResourceType,
+		client:        client,
+		catalog:       catalog,
+		perPermission: perPermission,
 	}
 }
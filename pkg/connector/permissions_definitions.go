@@ -1,75 +1,508 @@
 package connector
 
-// permissionDefinition defines the structure of a DocuSign permission.
-type permissionDefinition struct {
-	ID          string
-	DisplayName string
-	Description string
-}
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 
-// permissionMapping maps DocuSign user settings fields to permission IDs.
-type permissionMapping struct {
-	FieldName    string
-	PermissionID string
-}
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
 
 // permissionResourceID is the singleton ID for the permissions resource.
 const (
 	permissionResourceID = "docusign-permissions"
 )
 
-// permissionDefinitions contains all possible DocuSign permissions with their metadata.
-var permissionDefinitions = []permissionDefinition{
-	{"adminOnly", "Admin Only Actions", "Indicates some actions are exclusive for admins"},
-	{"canManageAccount", "Manage Account", "Can manage account settings"},
-	{"canManageTemplates", "Manage Templates", "Can manage shared templates"},
-	{"canEditSharedAddressbook", "Edit Shared Addressbook", "Can edit shared address book"},
-	{"canManageOrganization", "Manage Organization", "Can manage organization settings"},
-	{"canManageDistributor", "Manage Distributor", "Can manage distributor settings"},
-	{"canSendEnvelope", "Send Envelope", "Can send envelopes"},
-	{"canSignEnvelope", "Sign Envelope", "Can sign envelopes"},
-	{"allowSendOnBehalfOf", "Send On Behalf Of", "Can send envelopes on behalf of others"},
-	{"bulkSend", "Bulk Send", "Can send envelopes in bulk"},
-	{"canSendAPIRequests", "Send API Requests", "Can make API requests"},
-	{"enableSequentialSigningUI", "Sequential Signing UI", "Can use sequential signing UI"},
-	{"enableDSPro", "DS Pro Features", "Access to DocuSign Pro features"},
-	{"canUseScratchpad", "Use Scratchpad", "Can use scratchpad feature"},
-	{"canCreateWorkspaces", "Create Workspaces", "Can create collaborative workspaces"},
-	{"enableTransactionPoint", "Transaction Point", "Can use transaction point feature"},
-	{"powerFormMode", "PowerForm Admin", "Administrative control over PowerForms"},
-	{"apiCanExportAC", "Export Audit Certificates", "Can export audit certificates via API"},
-	{"enableVaulting", "Vaulting Access", "Can use long-term storage (Vaulting)"},
-	{"canUseSmartContracts", "Smart Contracts", "Can use smart contracts"},
-}
-
-// fieldToPermissionMappings maps user setting fields to permission IDs.
-var fieldToPermissionMappings = []permissionMapping{
-	{"isAdmin", "adminOnly"},
-	{"adminOnly", "adminOnly"},
-	{"canManageAccount", "canManageAccount"},
-	{"canManageTemplates", "canManageTemplates"},
-	{"canEditSharedAddressbook", "canEditSharedAddressbook"},
-	{"canManageOrganization", "canManageOrganization"},
-	{"canManageDistributor", "canManageDistributor"},
-	{"canSendEnvelope", "canSendEnvelope"},
-	{"canSignEnvelope", "canSignEnvelope"},
-	{"allowSendOnBehalfOf", "allowSendOnBehalfOf"},
-	{"bulkSend", "bulkSend"},
-	{"canSendAPIRequests", "canSendAPIRequests"},
-	{"enableSequentialSigningUI", "enableSequentialSigningUI"},
-	{"enableDSPro", "enableDSPro"},
-	{"canUseScratchpad", "canUseScratchpad"},
-	{"canCreateWorkspaces", "canCreateWorkspaces"},
-	{"enableTransactionPoint", "enableTransactionPoint"},
-	{"powerFormMode", "powerFormMode"},
-	{"apiCanExportAC", "apiCanExportAC"},
-	{"enableVaulting", "enableVaulting"},
-	{"canUseSmartContracts", "canUseSmartContracts"},
-}
-
-// validPermissionValues defines acceptable values for permission settings.
-var validPermissionValues = map[string]bool{
-	"true":  true,
-	"admin": true,
-	"share": true,
+// defaultPermissionCatalogJSON is the built-in permission catalog.
+//
+//go:embed permission_catalog.json
+var defaultPermissionCatalogJSON []byte
+
+// permissionCatalogFile is the format of the built-in catalog and of user-supplied override files.
+type permissionCatalogFile struct {
+	// DefaultValues are the setting values granting a permission that defines no values of its own.
+	DefaultValues []string               `json:"defaultValues"`
+	Permissions   []permissionDefinition `json:"permissions"`
+}
+
+// permissionDefinition defines a DocuSign permission and the settings it is read from.
+// Settings are dot-separated paths for settings nested in a group, such as accountManagementGranular.
+// The first setting is the one written when the permission is granted or revoked.
+type permissionDefinition struct {
+	ID           string            `json:"id"`
+	Settings     []string          `json:"settings"`
+	Values       []string          `json:"values,omitempty"`
+	RevokedValue string            `json:"revokedValue,omitempty"`
+	DisplayName  string            `json:"displayName"`
+	Description  string            `json:"description"`
+	Sensitivity  string            `json:"sensitivity"`
+	Levels       []permissionLevel `json:"levels,omitempty"`
+	// ComplianceTags label the permission for compliance reviews, such as SOX.
+	ComplianceTags []string `json:"complianceTags,omitempty"`
+}
+
+// Sensitivity values of a permission, from the least to the most sensitive.
+const (
+	sensitivityLow      = "low"
+	sensitivityMedium   = "medium"
+	sensitivityHigh     = "high"
+	sensitivityCritical = "critical"
+)
+
+// defaultRevokedValue is the value written to revoke a permission that defines no revoked value.
+const defaultRevokedValue = "false"
+
+// settingChange is the setting write that grants or revokes a permission entitlement.
+type settingChange struct {
+	Path  string
+	Value string
+}
+
+// permissionLevel is one access level of a setting whose values grant different privileges.
+// Levels are listed from the lowest to the highest, and every level implies the ones below it.
+type permissionLevel struct {
+	Suffix      string   `json:"suffix"`
+	DisplayName string   `json:"displayName"`
+	Description string   `json:"description"`
+	Values      []string `json:"values"`
+	Sensitivity string   `json:"sensitivity,omitempty"`
+}
+
+// permissionEntitlement describes an entitlement exposed on the permissions resource.
+// PermissionID is the definition it belongs to and Suffix its access level, empty for permissions without levels.
+type permissionEntitlement struct {
+	Slug           string
+	PermissionID   string
+	Suffix         string
+	DisplayName    string
+	Description    string
+	Sensitivity    string
+	ComplianceTags []string
+}
+
+// permissionCatalog maps DocuSign settings to permission entitlements.
+type permissionCatalog struct {
+	defaultValues []string
+	definitions   []permissionDefinition
+	settings      map[string]bool
+	entitlements  map[string]permissionEntitlement
+
+	mu       sync.Mutex
+	reported map[string]bool
+}
+
+// defaultPermissionCatalog is the built-in catalog, used when no override file is configured.
+var defaultPermissionCatalog = mustParsePermissionCatalog(defaultPermissionCatalogJSON)
+
+// catalogOrDefault returns the catalog, or the built-in one when it is nil.
+func catalogOrDefault(catalog *permissionCatalog) *permissionCatalog {
+	if catalog == nil {
+		return defaultPermissionCatalog
+	}
+	return catalog
+}
+
+// loadPermissionCatalog returns the built-in catalog extended by the override file at path.
+// Entries of the override file replace the built-in permission with the same ID, other entries are added.
+func loadPermissionCatalog(path string) (*permissionCatalog, error) {
+	if path == "" {
+		return defaultPermissionCatalog, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read permission catalog %s: %w", path, err)
+	}
+
+	var base permissionCatalogFile
+	if err := json.Unmarshal(defaultPermissionCatalogJSON, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse the built-in permission catalog: %w", err)
+	}
+
+	var override permissionCatalogFile
+	if err := json.Unmarshal(data, &override); err != nil {
+		return nil, fmt.Errorf("failed to parse permission catalog %s: %w", path, err)
+	}
+
+	if len(override.DefaultValues) > 0 {
+		base.DefaultValues = override.DefaultValues
+	}
+	for _, definition := range override.Permissions {
+		index := slices.IndexFunc(base.Permissions, func(d permissionDefinition) bool { return d.ID == definition.ID })
+		if index >= 0 {
+			base.Permissions[index] = definition
+		} else {
+			base.Permissions = append(base.Permissions, definition)
+		}
+	}
+
+	catalog, err := newPermissionCatalog(base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid permission catalog %s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+// mustParsePermissionCatalog parses the built-in catalog, which is validated by the tests.
+func mustParsePermissionCatalog(data []byte) *permissionCatalog {
+	var file permissionCatalogFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		panic(fmt.Sprintf("failed to parse the built-in permission catalog: %v", err))
+	}
+	catalog, err := newPermissionCatalog(file)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in permission catalog: %v", err))
+	}
+	return catalog
+}
+
+// newPermissionCatalog validates the catalog file and builds a permissionCatalog from it.
+func newPermissionCatalog(file permissionCatalogFile) (*permissionCatalog, error) {
+	catalog := &permissionCatalog{
+		defaultValues: lowerAll(file.DefaultValues),
+		settings:      map[string]bool{},
+		reported:      map[string]bool{},
+	}
+
+	ids := map[string]bool{}
+	for _, definition := range file.Permissions {
+		if definition.ID == "" {
+			return nil, fmt.Errorf("permission without an id")
+		}
+		if definition.ID == permissionResourceID {
+			return nil, fmt.Errorf("permission id %s is reserved", definition.ID)
+		}
+		if ids[definition.ID] {
+			return nil, fmt.Errorf("duplicate permission %s", definition.ID)
+		}
+		ids[definition.ID] = true
+		if len(definition.Settings) == 0 {
+			return nil, fmt.Errorf("permission %s has no settings", definition.ID)
+		}
+		if definition.DisplayName == "" {
+			return nil, fmt.Errorf("permission %s has no display name", definition.ID)
+		}
+		if !isValidSensitivity(definition.Sensitivity) {
+			return nil, fmt.Errorf("permission %s has an unknown sensitivity %q", definition.ID, definition.Sensitivity)
+		}
+
+		definition.Values = lowerAll(definition.Values)
+		for i, level := range definition.Levels {
+			if level.Suffix == "" || len(level.Values) == 0 {
+				return nil, fmt.Errorf("permission %s has a level without a suffix or values", definition.ID)
+			}
+			if !isValidSensitivity(level.Sensitivity) {
+				return nil, fmt.Errorf("permission %s has a level with an unknown sensitivity %q", definition.ID, level.Sensitivity)
+			}
+			definition.Levels[i].Values = lowerAll(level.Values)
+		}
+		for _, setting := range definition.Settings {
+			catalog.settings[setting] = true
+		}
+		catalog.definitions = append(catalog.definitions, definition)
+	}
+
+	catalog.entitlements = map[string]permissionEntitlement{}
+	for _, permission := range catalog.Entitlements() {
+		catalog.entitlements[permission.Slug] = permission
+	}
+
+	return catalog, nil
+}
+
+// Definitions returns the permission definitions in catalog order.
+func (c *permissionCatalog) Definitions() []permissionDefinition {
+	return c.definitions
+}
+
+// Entitlements returns every entitlement of the permissions resource, one per access level for leveled permissions.
+func (c *permissionCatalog) Entitlements() []permissionEntitlement {
+	entitlements := make([]permissionEntitlement, 0, len(c.definitions))
+	for _, definition := range c.definitions {
+		if len(definition.Levels) == 0 {
+			entitlements = append(entitlements, permissionEntitlement{
+				Slug:           definition.ID,
+				PermissionID:   definition.ID,
+				DisplayName:    definition.DisplayName,
+				Description:    definition.Description,
+				Sensitivity:    definition.Sensitivity,
+				ComplianceTags: definition.ComplianceTags,
+			})
+			continue
+		}
+		for i, level := range definition.Levels {
+			sensitivity := level.Sensitivity
+			if sensitivity == "" {
+				sensitivity = definition.Sensitivity
+			}
+			entitlements = append(entitlements, permissionEntitlement{
+				Slug:           definition.levelSlug(i),
+				PermissionID:   definition.ID,
+				Suffix:         level.Suffix,
+				DisplayName:    level.DisplayName,
+				Description:    level.Description,
+				Sensitivity:    sensitivity,
+				ComplianceTags: definition.ComplianceTags,
+			})
+		}
+	}
+	return entitlements
+}
+
+// Entitlement returns the entitlement of the permission or access level slug.
+func (c *permissionCatalog) Entitlement(slug string) (permissionEntitlement, bool) {
+	permission, ok := c.entitlements[slug]
+	return permission, ok
+}
+
+// Granted returns the permission entitlement slugs granted by a settings map, in catalog order, with their access level.
+func (c *permissionCatalog) Granted(settingsMap map[string]interface{}) ([]string, map[string]string) {
+	var slugs []string
+	levels := map[string]string{}
+	for _, definition := range c.definitions {
+		for _, setting := range definition.Settings {
+			value, exists := lookupSetting(settingsMap, setting)
+			if !exists {
+				continue
+			}
+			if slug, accessLevel, hasPermission := c.resolve(definition, value); hasPermission {
+				slugs = append(slugs, slug)
+				levels[slug] = accessLevel
+				break
+			}
+		}
+	}
+	return slugs, levels
+}
+
+// Critical returns the slugs of the critical permissions granted by a settings map, in catalog order.
+func (c *permissionCatalog) Critical(settingsMap map[string]interface{}) []string {
+	slugs, _ := c.Granted(settingsMap)
+	var critical []string
+	for _, slug := range slugs {
+		if c.entitlements[slug].Sensitivity == sensitivityCritical {
+			critical = append(critical, slug)
+		}
+	}
+	return critical
+}
+
+// Resolve returns the entitlement slug a value of the permission's setting grants and its normalized access level.
+func (c *permissionCatalog) Resolve(permissionID string, value interface{}) (string, string, bool) {
+	for _, definition := range c.definitions {
+		if definition.ID == permissionID {
+			return c.resolve(definition, value)
+		}
+	}
+	return "", "", false
+}
+
+// resolve maps a setting value to the entitlement of the matching level for leveled permissions,
+// or to the permission itself when the value is one of its accepted values.
+func (c *permissionCatalog) resolve(definition permissionDefinition, value interface{}) (string, string, bool) {
+	accessLevel := normalizePermissionValue(value)
+
+	if len(definition.Levels) == 0 {
+		values := definition.Values
+		if len(values) == 0 {
+			values = c.defaultValues
+		}
+		return definition.ID, accessLevel, slices.Contains(values, accessLevel)
+	}
+	for i, level := range definition.Levels {
+		if slices.Contains(level.Values, accessLevel) {
+			return definition.levelSlug(i), accessLevel, true
+		}
+	}
+	return "", accessLevel, false
+}
+
+// GrantChange returns the setting write granting the entitlement.
+func (c *permissionCatalog) GrantChange(slug string) (settingChange, error) {
+	definition, levelIndex, ok := c.lookupEntitlement(slug)
+	if !ok {
+		return settingChange{}, fmt.Errorf("unknown permission %s", slug)
+	}
+
+	if levelIndex >= 0 {
+		return settingChange{Path: definition.Settings[0], Value: definition.Levels[levelIndex].Values[0]}, nil
+	}
+
+	values := definition.Values
+	if len(values) == 0 {
+		values = c.defaultValues
+	}
+	if len(values) == 0 {
+		return settingChange{}, fmt.Errorf("permission %s has no accepted values", slug)
+	}
+	return settingChange{Path: definition.Settings[0], Value: values[0]}, nil
+}
+
+// RevokeChange returns the setting write revoking the entitlement. Revoking an access level lowers
+// the setting to the level below it, or to the revoked value for the lowest level.
+func (c *permissionCatalog) RevokeChange(slug string) (settingChange, error) {
+	definition, levelIndex, ok := c.lookupEntitlement(slug)
+	if !ok {
+		return settingChange{}, fmt.Errorf("unknown permission %s", slug)
+	}
+
+	if levelIndex > 0 {
+		return settingChange{Path: definition.Settings[0], Value: definition.Levels[levelIndex-1].Values[0]}, nil
+	}
+
+	value := definition.RevokedValue
+	if value == "" {
+		value = defaultRevokedValue
+	}
+	return settingChange{Path: definition.Settings[0], Value: value}, nil
+}
+
+// Holds reports whether the settings grant the entitlement, directly or through a higher access level.
+func (c *permissionCatalog) Holds(settingsMap map[string]interface{}, slug string) bool {
+	definition, levelIndex, ok := c.lookupEntitlement(slug)
+	if !ok {
+		return false
+	}
+
+	granted, _ := c.Granted(settingsMap)
+	for _, grantedSlug := range granted {
+		grantedDefinition, grantedIndex, _ := c.lookupEntitlement(grantedSlug)
+		if grantedDefinition.ID == definition.ID && grantedIndex >= levelIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupEntitlement finds the permission of an entitlement slug and the index of its access level, -1 when not leveled.
+func (c *permissionCatalog) lookupEntitlement(slug string) (permissionDefinition, int, bool) {
+	for _, definition := range c.definitions {
+		if len(definition.Levels) == 0 {
+			if definition.ID == slug {
+				return definition, -1, true
+			}
+			continue
+		}
+		for i := range definition.Levels {
+			if definition.levelSlug(i) == slug {
+				return definition, i, true
+			}
+		}
+	}
+	return permissionDefinition{}, 0, false
+}
+
+// LevelImplications returns, for each access level below the highest, the slug of the level directly above it.
+func (c *permissionCatalog) LevelImplications() [][2]string {
+	var implications [][2]string
+	for _, definition := range c.definitions {
+		for i := 0; i+1 < len(definition.Levels); i++ {
+			implications = append(implications, [2]string{
+				definition.levelSlug(i),
+				definition.levelSlug(i + 1),
+			})
+		}
+	}
+	return implications
+}
+
+// ReportUnknownSettings logs, once per sync, the boolean settings the catalog does not map so they can be added to it.
+func (c *permissionCatalog) ReportUnknownSettings(ctx context.Context, settingsMap map[string]interface{}) {
+	var unknown []string
+	collectBooleanSettings(settingsMap, "", func(path string) {
+		if !c.settings[path] {
+			unknown = append(unknown, path)
+		}
+	})
+	if len(unknown) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	l := ctxzap.Extract(ctx)
+	sort.Strings(unknown)
+	for _, path := range unknown {
+		if c.reported[path] {
+			continue
+		}
+		c.reported[path] = true
+		l.Info("docusign-connector: boolean setting is not in the permission catalog", zap.String("setting", path))
+	}
+}
+
+// resetReported forgets the settings reported during the previous sync, so they are logged again by the next one.
+func (c *permissionCatalog) resetReported() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reported = map[string]bool{}
+}
+
+// collectBooleanSettings calls found with the dot-separated path of every boolean setting of the map.
+func collectBooleanSettings(settingsMap map[string]interface{}, prefix string, found func(path string)) {
+	for key, value := range settingsMap {
+		path := prefix + key
+		switch v := value.(type) {
+		case map[string]interface{}:
+			collectBooleanSettings(v, path+".", found)
+		case bool:
+			found(path)
+		case string:
+			if lower := strings.ToLower(v); lower == "true" || lower == "false" {
+				found(path)
+			}
+		}
+	}
+}
+
+// isValidSensitivity reports whether sensitivity is empty or one of the known sensitivity values.
+func isValidSensitivity(sensitivity string) bool {
+	switch sensitivity {
+	case "", sensitivityLow, sensitivityMedium, sensitivityHigh, sensitivityCritical:
+		return true
+	default:
+		return false
+	}
+}
+
+// levelSlug returns the entitlement slug of the access level at index. The highest level keeps the permission ID,
+// the slug the permission had before it was split into levels, so its existing grants keep their IDs.
+func (d permissionDefinition) levelSlug(index int) string {
+	if index == len(d.Levels)-1 {
+		return d.ID
+	}
+	return d.ID + "." + d.Levels[index].Suffix
+}
+
+// levelSlugForSuffix returns the entitlement slug of the permission's access level with the suffix.
+func (c *permissionCatalog) levelSlugForSuffix(permissionID, suffix string) string {
+	for _, definition := range c.definitions {
+		if definition.ID != permissionID {
+			continue
+		}
+		for i, level := range definition.Levels {
+			if level.Suffix == suffix {
+				return definition.levelSlug(i)
+			}
+		}
+	}
+	return permissionID + "." + suffix
+}
+
+// lowerAll returns the values in lower case.
+func lowerAll(values []string) []string {
+	lowered := make([]string, 0, len(values))
+	for _, value := range values {
+		lowered = append(lowered, strings.ToLower(value))
+	}
+	return lowered
 }
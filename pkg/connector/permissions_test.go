@@ -1,12 +1,25 @@
 package connector
 
 import (
+	"bytes"
 	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // TestPermissionBuilder_List tests the List method of permissionBuilder.
@@ -58,8 +71,8 @@ func TestPermissionBuilder_GetPermissionResource(t *testing.T) {
 
 // TestPermissionBuilder_EntitlementsAndGrants tests both Entitlements and Grants methods.
 // Verifies that for permission resources:.
-// - No entitlements are returned.
-// - No grants are returned.
+// - Entitlements are returned, one per access level for leveled permissions.
+// - Each access level grant is expandable from the level above it.
 // - Proper empty responses are provided for tokens and annotations.
 func TestPermissionBuilder_EntitlementsAndGrants(t *testing.T) {
 	mockClient := &client.Client{}
@@ -85,10 +98,444 @@ func TestPermissionBuilder_EntitlementsAndGrants(t *testing.T) {
 		assert.Equal(t, resource.Id.ResourceType, e.Resource.Id.ResourceType, "ResourceType should match")
 	}
 
+	entitlementIDs := make([]string, 0, len(entitlements))
+	for _, e := range entitlements {
+		entitlementIDs = append(entitlementIDs, e.Id)
+	}
+	// The highest access level keeps the ID the permission had before it was split into levels.
+	assert.Contains(t, entitlementIDs, "permission:docusign-permissions:powerFormMode.user")
+	assert.Contains(t, entitlementIDs, "permission:docusign-permissions:powerFormMode")
+	assert.NotContains(t, entitlementIDs, "permission:docusign-permissions:powerFormMode.admin")
+
 	// Test Grants.
 	grants, nextGrantToken, grantAnnos, grantErr := builder.Grants(ctx, resource, nil)
 	assert.NoError(t, grantErr)
-	assert.Empty(t, grants)
 	assert.Empty(t, nextGrantToken)
 	assert.Nil(t, grantAnnos)
+
+	implied := map[string][]string{}
+	for _, g := range grants {
+		expandable := &v2.GrantExpandable{}
+		grantAnnos := annotations.Annotations(g.Annotations)
+		ok, err := grantAnnos.Pick(expandable)
+		require.NoError(t, err)
+		require.True(t, ok)
+		implied[g.Entitlement.Id] = expandable.EntitlementIds
+	}
+	assert.Equal(t, []string{"permission:docusign-permissions:powerFormMode"}, implied["permission:docusign-permissions:powerFormMode.user"])
+	assert.Equal(t, []string{"permission:docusign-permissions:canManageTemplates"}, implied["permission:docusign-permissions:canManageTemplates.create"])
+	assert.NotContains(t, implied, "permission:docusign-permissions:canManageTemplates")
+}
+
+// TestCreateUserGrants_AccountManagementGranular verifies the nested administrative rights are granted.
+func TestCreateUserGrants_AccountManagementGranular(t *testing.T) {
+	builder := newPermissionBuilder(nil, nil, false)
+
+	user := &client.UserDetail{
+		UserID: "u1",
+		UserSettings: client.UserSettings{
+			CanSendEnvelope: "true",
+			AccountManagementGranular: client.AccountManagement{
+				CanManageUsers:  "true",
+				CanManageAdmins: "false",
+				CanViewUsers:    "true",
+			},
+		},
+	}
+
+	settingsMap, err := parseUserSettings(user.UserSettings)
+	require.NoError(t, err)
+
+	grants := createUserGrants(builder, user, settingsMap, nil, nil)
+
+	var entitlementIDs []string
+	for _, g := range grants {
+		entitlementIDs = append(entitlementIDs, g.Entitlement.Id)
+	}
+	assert.ElementsMatch(t, []string{
+		"permission:docusign-permissions:canSendEnvelope",
+		"permission:docusign-permissions:canManageUsers",
+		"permission:docusign-permissions:canViewUsers",
+	}, entitlementIDs)
+}
+
+// TestResolvePermission verifies setting values resolve to the entitlement of their access level.
+func TestResolvePermission(t *testing.T) {
+	tests := []struct {
+		permissionID string
+		value        interface{}
+		wantSlug     string
+		wantGranted  bool
+	}{
+		{"powerFormMode", "admin", "powerFormMode", true},
+		{"powerFormMode", "user", "powerFormMode.user", true},
+		{"powerFormMode", "none", "", false},
+		{"canManageTemplates", "create", "canManageTemplates.create", true},
+		{"canEditSharedAddressbook", "use_private_and_shared", "canEditSharedAddressbook.use", true},
+		{"canSendEnvelope", "true", "canSendEnvelope", true},
+		{"canSendEnvelope", "false", "canSendEnvelope", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.permissionID+"="+tt.value.(string), func(t *testing.T) {
+			slug, _, granted := defaultPermissionCatalog.Resolve(tt.permissionID, tt.value)
+			assert.Equal(t, tt.wantGranted, granted)
+			if tt.wantGranted {
+				assert.Equal(t, tt.wantSlug, slug)
+			}
+		})
+	}
+}
+
+// TestLoadPermissionCatalog verifies an override file replaces built-in permissions and adds new ones.
+// TestPermissionCatalog_ReportUnknownSettings verifies unknown settings are logged once per sync.
+func TestPermissionCatalog_ReportUnknownSettings(t *testing.T) {
+	var logs bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&logs), zap.InfoLevel)
+	ctx := ctxzap.ToContext(context.Background(), zap.New(core))
+	catalog := mustParsePermissionCatalog(defaultPermissionCatalogJSON)
+	settings := map[string]interface{}{"canSendEnvelope": "true", "canUseNewFeature": "true"}
+
+	catalog.ReportUnknownSettings(ctx, settings)
+	catalog.ReportUnknownSettings(ctx, settings)
+	assert.Equal(t, 1, bytes.Count(logs.Bytes(), []byte(`"setting":"canUseNewFeature"`)))
+
+	catalog.resetReported()
+	catalog.ReportUnknownSettings(ctx, settings)
+	assert.Equal(t, 2, bytes.Count(logs.Bytes(), []byte(`"setting":"canUseNewFeature"`)))
+}
+
+func TestLoadPermissionCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"permissions": [
+			{"id": "canSendEnvelope", "settings": ["canSendEnvelope"], "displayName": "Send Envelopes", "description": "Can send", "sensitivity": "high"},
+			{"id": "canUseNewFeature", "settings": ["featureGroup.canUseNewFeature"], "values": ["enabled"], "displayName": "New Feature", "description": "Can use the new feature", "sensitivity": "low"}
+		]
+	}`), 0o600))
+
+	catalog, err := loadPermissionCatalog(path)
+	require.NoError(t, err)
+
+	entitlements := map[string]permissionEntitlement{}
+	for _, e := range catalog.Entitlements() {
+		entitlements[e.Slug] = e
+	}
+	assert.Len(t, entitlements, len(defaultPermissionCatalog.Entitlements())+1)
+	assert.Equal(t, "Send Envelopes", entitlements["canSendEnvelope"].DisplayName)
+	assert.Equal(t, "high", entitlements["canSendEnvelope"].Sensitivity)
+
+	slugs, _ := catalog.Granted(map[string]interface{}{
+		"featureGroup": map[string]interface{}{"canUseNewFeature": "Enabled"},
+	})
+	assert.Equal(t, []string{"canUseNewFeature"}, slugs)
+
+	t.Run("rejects invalid entries", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "invalid.json")
+		require.NoError(t, os.WriteFile(invalid, []byte(`{"permissions": [{"id": "broken", "displayName": "Broken"}]}`), 0o600))
+
+		_, err := loadPermissionCatalog(invalid)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects unknown sensitivities", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "invalid.json")
+		require.NoError(t, os.WriteFile(invalid, []byte(`{"permissions": [{"id": "bulkSend", "settings": ["bulkSend"], "displayName": "Bulk Send", "sensitivity": "severe"}]}`), 0o600))
+
+		_, err := loadPermissionCatalog(invalid)
+		require.Error(t, err)
+	})
+
+	t.Run("uses the built-in catalog without a file", func(t *testing.T) {
+		catalog, err := loadPermissionCatalog("")
+		require.NoError(t, err)
+		assert.Same(t, defaultPermissionCatalog, catalog)
+	})
+}
+
+// mockPermissionClient implements PermissionClient with in-memory user settings.
+type mockPermissionClient struct {
+	settings map[string]interface{}
+	updates  []map[string]interface{}
+	ignore   bool
+}
+
+func (m *mockPermissionClient) GetUserSettings(ctx context.Context, userID string) (map[string]interface{}, annotations.Annotations, error) {
+	return m.settings, nil, nil
+}
+
+func (m *mockPermissionClient) UpdateUserSettings(ctx context.Context, userID string, settings map[string]interface{}) (annotations.Annotations, error) {
+	m.updates = append(m.updates, settings)
+	if !m.ignore {
+		mergeSettings(m.settings, settings)
+	}
+	return nil, nil
+}
+
+func mergeSettings(dst, src map[string]interface{}) {
+	for key, value := range src {
+		if nested, ok := value.(map[string]interface{}); ok {
+			if _, ok := dst[key].(map[string]interface{}); !ok {
+				dst[key] = map[string]interface{}{}
+			}
+			mergeSettings(dst[key].(map[string]interface{}), nested)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+func newTestPermissionEntitlement(t *testing.T, slug string) *v2.Entitlement {
+	builder := newPermissionBuilder(nil, nil, false)
+	permissionResource, err := builder.GetPermissionResource(context.Background())
+	require.NoError(t, err)
+
+	ents, _, _, err := builder.Entitlements(context.Background(), permissionResource, nil)
+	require.NoError(t, err)
+	for _, ent := range ents {
+		if ent.Slug == slug {
+			return ent
+		}
+	}
+	require.Failf(t, "entitlement not found", slug)
+	return nil
+}
+
+// TestPermissionBuilder_Grant verifies permissions are granted by writing the mapped setting and confirmed by a re-read.
+func TestPermissionBuilder_Grant(t *testing.T) {
+	user := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}
+
+	t.Run("sets a nested granular admin flag", func(t *testing.T) {
+		permissionClient := &mockPermissionClient{settings: map[string]interface{}{"canSendEnvelope": "true"}}
+		builder := newPermissionBuilder(permissionClient, nil, false)
+
+		grants, _, err := builder.Grant(context.Background(), user, newTestPermissionEntitlement(t, "canManageUsers"))
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, []map[string]interface{}{
+			{"accountManagementGranular": map[string]interface{}{"canManageUsers": "true"}},
+		}, permissionClient.updates)
+	})
+
+	t.Run("sets the value of an access level", func(t *testing.T) {
+		permissionClient := &mockPermissionClient{settings: map[string]interface{}{"powerFormMode": "none"}}
+		builder := newPermissionBuilder(permissionClient, nil, false)
+
+		_, _, err := builder.Grant(context.Background(), user, newTestPermissionEntitlement(t, "powerFormMode"))
+		require.NoError(t, err)
+		assert.Equal(t, "admin", permissionClient.settings["powerFormMode"])
+	})
+
+	t.Run("reports permissions held through a higher level", func(t *testing.T) {
+		permissionClient := &mockPermissionClient{settings: map[string]interface{}{"powerFormMode": "admin"}}
+		builder := newPermissionBuilder(permissionClient, nil, false)
+
+		grants, annos, err := builder.Grant(context.Background(), user, newTestPermissionEntitlement(t, "powerFormMode.user"))
+		require.NoError(t, err)
+		assert.Empty(t, grants)
+		assert.True(t, annos.Contains(&v2.GrantAlreadyExists{}))
+		assert.Empty(t, permissionClient.updates)
+	})
+
+	t.Run("writes the first mapped setting", func(t *testing.T) {
+		permissionClient := &mockPermissionClient{settings: map[string]interface{}{"isAdmin": "false", "adminOnly": "false"}}
+		builder := newPermissionBuilder(permissionClient, nil, false)
+
+		_, _, err := builder.Grant(context.Background(), user, newTestPermissionEntitlement(t, "adminOnly"))
+		require.NoError(t, err)
+		assert.Equal(t, []map[string]interface{}{{"isAdmin": "true"}}, permissionClient.updates)
+	})
+
+	t.Run("fails when the setting does not take effect", func(t *testing.T) {
+		permissionClient := &mockPermissionClient{settings: map[string]interface{}{"bulkSend": "false"}, ignore: true}
+		builder := newPermissionBuilder(permissionClient, nil, false)
+
+		_, _, err := builder.Grant(context.Background(), user, newTestPermissionEntitlement(t, "bulkSend"))
+		require.Error(t, err)
+	})
+}
+
+// TestPermissionBuilder_Revoke verifies permissions are revoked by clearing the setting or lowering the access level.
+func TestPermissionBuilder_Revoke(t *testing.T) {
+	newUserGrant := func(t *testing.T, slug string) *v2.Grant {
+		return &v2.Grant{
+			Entitlement: newTestPermissionEntitlement(t, slug),
+			Principal:   &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}},
+		}
+	}
+
+	t.Run("clears a boolean setting", func(t *testing.T) {
+		permissionClient := &mockPermissionClient{settings: map[string]interface{}{"bulkSend": "true"}}
+		builder := newPermissionBuilder(permissionClient, nil, false)
+
+		_, err := builder.Revoke(context.Background(), newUserGrant(t, "bulkSend"))
+		require.NoError(t, err)
+		assert.Equal(t, "false", permissionClient.settings["bulkSend"])
+	})
+
+	t.Run("lowers an access level", func(t *testing.T) {
+		permissionClient := &mockPermissionClient{settings: map[string]interface{}{"canManageTemplates": "share"}}
+		builder := newPermissionBuilder(permissionClient, nil, false)
+
+		_, err := builder.Revoke(context.Background(), newUserGrant(t, "canManageTemplates"))
+		require.NoError(t, err)
+		assert.Equal(t, "create", permissionClient.settings["canManageTemplates"])
+	})
+
+	t.Run("reports permissions already revoked", func(t *testing.T) {
+		permissionClient := &mockPermissionClient{settings: map[string]interface{}{"bulkSend": "false"}}
+		builder := newPermissionBuilder(permissionClient, nil, false)
+
+		annos, err := builder.Revoke(context.Background(), newUserGrant(t, "bulkSend"))
+		require.NoError(t, err)
+		assert.True(t, annos.Contains(&v2.GrantAlreadyRevoked{}))
+		assert.Empty(t, permissionClient.updates)
+	})
+}
+
+// TestPermissionBuilder_PerPermission verifies each catalog permission is synced as its own role resource.
+func TestPermissionBuilder_PerPermission(t *testing.T) {
+	ctx := context.Background()
+	builder := newPermissionBuilder(nil, nil, true)
+
+	resources, _, _, err := builder.List(ctx, nil, &pagination.Token{})
+	require.NoError(t, err)
+	require.Len(t, resources, len(defaultPermissionCatalog.Definitions()))
+
+	byID := map[string]*v2.Resource{}
+	for _, r := range resources {
+		byID[r.Id.Resource] = r
+	}
+	require.Contains(t, byID, "canManageUsers")
+	require.Contains(t, byID, "powerFormMode")
+	assert.NotContains(t, byID, permissionResourceID)
+	assert.NotEmpty(t, byID["canManageUsers"].Description)
+
+	roleTrait, err := rs.GetRoleTrait(byID["canManageUsers"])
+	require.NoError(t, err)
+	assert.Equal(t, "critical", roleTrait.Profile.GetFields()["sensitivity"].GetStringValue())
+
+	entitlementSlugs := func(r *v2.Resource) []string {
+		ents, _, _, err := builder.Entitlements(ctx, r, nil)
+		require.NoError(t, err)
+		var slugs []string
+		for _, ent := range ents {
+			slugs = append(slugs, ent.Slug)
+		}
+		return slugs
+	}
+	assert.Equal(t, []string{"has"}, entitlementSlugs(byID["canManageUsers"]))
+	assert.Equal(t, []string{"user", "admin"}, entitlementSlugs(byID["powerFormMode"]))
+
+	grants, _, _, err := builder.Grants(ctx, byID["powerFormMode"], nil)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, "permission:powerFormMode:user", grants[0].Entitlement.Id)
+
+	t.Run("user grants record the singleton entitlement ID", func(t *testing.T) {
+		user := &client.UserDetail{UserID: "u1"}
+		settingsMap := map[string]interface{}{
+			"accountManagementGranular": map[string]interface{}{"canManageUsers": "true"},
+			"powerFormMode":             "admin",
+		}
+
+		legacyIDs := map[string]string{}
+		for _, g := range createUserGrants(builder, user, settingsMap, nil, nil) {
+			metadata := &v2.GrantMetadata{}
+			annos := annotations.Annotations(g.Annotations)
+			ok, err := annos.Pick(metadata)
+			require.NoError(t, err)
+			require.True(t, ok)
+			legacyIDs[g.Entitlement.Id] = metadata.Metadata.GetFields()["legacy_entitlement_id"].GetStringValue()
+		}
+		assert.Equal(t, map[string]string{
+			"permission:canManageUsers:has":  "permission:docusign-permissions:canManageUsers",
+			"permission:powerFormMode:admin": "permission:docusign-permissions:powerFormMode",
+		}, legacyIDs)
+	})
+
+	t.Run("every singleton entitlement ID maps to exactly one per-permission ID", func(t *testing.T) {
+		singleton := newPermissionBuilder(nil, nil, false)
+		userID := makeUserSubjectID("u1")
+
+		newIDs := map[string]string{}
+		for _, permission := range defaultPermissionCatalog.Entitlements() {
+			oldGrant := singleton.newPermissionGrant(permission.Slug, userID, map[string]interface{}{})
+			newGrant := builder.newPermissionGrant(permission.Slug, userID, map[string]interface{}{})
+
+			metadata := &v2.GrantMetadata{}
+			annos := annotations.Annotations(newGrant.Annotations)
+			ok, err := annos.Pick(metadata)
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, oldGrant.Entitlement.Id, metadata.Metadata.GetFields()["legacy_entitlement_id"].GetStringValue())
+
+			assert.NotContains(t, newIDs, oldGrant.Entitlement.Id)
+			newIDs[oldGrant.Entitlement.Id] = newGrant.Entitlement.Id
+		}
+
+		assert.Equal(t, "permission:canManageUsers:has", newIDs["permission:docusign-permissions:canManageUsers"])
+		assert.Equal(t, "permission:powerFormMode:user", newIDs["permission:docusign-permissions:powerFormMode.user"])
+		assert.Equal(t, "permission:powerFormMode:admin", newIDs["permission:docusign-permissions:powerFormMode"])
+		assert.Len(t, slices.Compact(slices.Sorted(maps.Values(newIDs))), len(newIDs))
+	})
+
+	t.Run("revokes grants synced in singleton mode", func(t *testing.T) {
+		permissionClient := &mockPermissionClient{settings: map[string]interface{}{"bulkSend": "true"}}
+		builder := newPermissionBuilder(permissionClient, nil, true)
+
+		_, err := builder.Revoke(ctx, &v2.Grant{
+			Entitlement: newTestPermissionEntitlement(t, "bulkSend"),
+			Principal:   &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "false", permissionClient.settings["bulkSend"])
+	})
+
+	t.Run("grants an access level of a permission resource", func(t *testing.T) {
+		permissionClient := &mockPermissionClient{settings: map[string]interface{}{"canManageTemplates": "none"}}
+		builder := newPermissionBuilder(permissionClient, nil, true)
+
+		_, _, err := builder.Grant(ctx, &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}, &v2.Entitlement{
+			Resource: byID["canManageTemplates"],
+			Slug:     "create",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "create", permissionClient.settings["canManageTemplates"])
+	})
+}
+
+// TestPermissionBuilder_RiskMetadata verifies the sensitivity and compliance tags of a permission
+// are added to the metadata of its grants and leave the entitlement description unchanged.
+func TestPermissionBuilder_RiskMetadata(t *testing.T) {
+	ent := newTestPermissionEntitlement(t, "canManageAdmins")
+	definition, ok := catalogOrDefault(nil).Entitlement("canManageAdmins")
+	require.True(t, ok)
+	assert.Equal(t, definition.Description, ent.Description)
+	assert.Empty(t, ent.Annotations)
+
+	builder := newPermissionBuilder(nil, nil, false)
+	grants := createUserGrants(builder, &client.UserDetail{UserID: "u1"}, map[string]interface{}{
+		"canSendEnvelope":           "true",
+		"accountManagementGranular": map[string]interface{}{"canManageAdmins": "true"},
+	}, nil, nil)
+	require.Len(t, grants, 2)
+
+	metadata := map[string]map[string]*structpb.Value{}
+	for _, g := range grants {
+		grantMetadata := &v2.GrantMetadata{}
+		annos := annotations.Annotations(g.Annotations)
+		ok, err := annos.Pick(grantMetadata)
+		require.NoError(t, err)
+		require.True(t, ok)
+		metadata[g.Entitlement.Id] = grantMetadata.Metadata.GetFields()
+	}
+
+	admins := metadata["permission:docusign-permissions:canManageAdmins"]
+	assert.Equal(t, "critical", admins["sensitivity"].GetStringValue())
+	assert.Equal(t, "SOX", admins["compliance_tags"].GetStringValue())
+
+	send := metadata["permission:docusign-permissions:canSendEnvelope"]
+	assert.NotEmpty(t, send["sensitivity"].GetStringValue())
+	assert.NotContains(t, send, "compliance_tags")
 }
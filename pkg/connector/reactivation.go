@@ -0,0 +1,48 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+// UserReactivationClient defines the methods required to reactivate closed users before a grant.
+type UserReactivationClient interface {
+	GetUserDetails(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error)
+	UpdateUser(ctx context.Context, userID string, update client.UserUpdate) (annotations.Annotations, error)
+}
+
+// isUserClosed reports whether the DocuSign user has been closed.
+func isUserClosed(user *client.UserDetail) bool {
+	return strings.EqualFold(user.UserStatus, "closed")
+}
+
+// reactivateClosedUser reactivates the user when it is closed, so a grant to a returning user can be applied.
+// It returns the grant metadata recording the reactivation, which is nil when the user wasn't closed.
+func reactivateClosedUser(ctx context.Context, reactivationClient UserReactivationClient, user *client.UserDetail) (map[string]interface{}, annotations.Annotations, error) {
+	if !isUserClosed(user) {
+		return nil, nil, nil
+	}
+
+	annos, err := reactivationClient.UpdateUser(ctx, user.UserID, client.UserUpdate{UserStatus: "Active"})
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to reactivate closed user %s: %w", user.UserID, err)
+	}
+
+	ctxzap.Extract(ctx).Info("docusign-connector: reactivated closed user before granting it access",
+		zap.String("user_id", user.UserID),
+		zap.String("previous_user_status", user.UserStatus),
+	)
+	metadata := map[string]interface{}{
+		"reactivated_user_id":  user.UserID,
+		"previous_user_status": user.UserStatus,
+	}
+
+	user.UserStatus = "Active"
+	return metadata, annos, nil
+}
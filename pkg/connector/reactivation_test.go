@@ -0,0 +1,143 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	"github.com/conductorone/baton-docusign/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reactivatedUserID returns the user ID recorded in the reactivation metadata of the grant, or "" when there is none.
+func reactivatedUserID(t *testing.T, g *v2.Grant) string {
+	metadata := &v2.GrantMetadata{}
+	annos := annotations.Annotations(g.Annotations)
+	ok, err := annos.Pick(metadata)
+	require.NoError(t, err)
+	require.True(t, ok)
+	userID, _ := metadata.Metadata.AsMap()["reactivated_user_id"].(string)
+	return userID
+}
+
+// TestReactivateClosedUser verifies only closed users are reactivated, and the reactivation metadata is returned.
+func TestReactivateClosedUser(t *testing.T) {
+	var updates []client.UserUpdate
+	mockClient := &test.MockClient{
+		UpdateUserFunc: func(ctx context.Context, userID string, update client.UserUpdate) (annotations.Annotations, error) {
+			updates = append(updates, update)
+			return nil, nil
+		},
+	}
+
+	active := &client.UserDetail{UserID: "u1", UserStatus: "Active"}
+	metadata, _, err := reactivateClosedUser(context.Background(), mockClient, active)
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+	assert.Nil(t, metadata)
+
+	closed := &client.UserDetail{UserID: "u2", UserStatus: "Closed"}
+	metadata, _, err = reactivateClosedUser(context.Background(), mockClient, closed)
+	require.NoError(t, err)
+	assert.Equal(t, []client.UserUpdate{{UserStatus: "Active"}}, updates)
+	assert.Equal(t, map[string]interface{}{"reactivated_user_id": "u2", "previous_user_status": "Closed"}, metadata)
+	assert.Equal(t, "Active", closed.UserStatus)
+}
+
+// TestGroupBuilder_Grant_ReactivatesClosedUsers verifies closed users are reactivated before being added, only when enabled.
+func TestGroupBuilder_Grant_ReactivatesClosedUsers(t *testing.T) {
+	groupResource := &v2.Resource{Id: &v2.ResourceId{ResourceType: groupResourceType.Id, Resource: "g1"}, DisplayName: "Legal"}
+	ent := &v2.Entitlement{Resource: groupResource, Slug: entitlementGroupMember}
+	userRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}
+
+	var calls []string
+	mockClient := &test.MockClient{
+		GetUserDetailsFunc: func(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+			return &client.UserDetail{UserID: userID, UserStatus: "Closed"}, nil, nil
+		},
+		UpdateUserFunc: func(ctx context.Context, userID string, update client.UserUpdate) (annotations.Annotations, error) {
+			calls = append(calls, "reactivate "+userID)
+			return nil, nil
+		},
+		AddGroupUsersFunc: func(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error) {
+			calls = append(calls, "add "+userIDs[0])
+			return &client.GroupUsersResponse{Users: []client.GroupUser{{UserId: "u1"}}}, nil, nil
+		},
+	}
+
+	grants, _, err := newTestGroupBuilder(mockClient).Grant(context.Background(), userRes, ent)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, []string{"add u1"}, calls)
+	assert.Empty(t, reactivatedUserID(t, grants[0]))
+
+	calls = nil
+	grants, _, err = newTestGroupBuilder(mockClient).withClosedUserReactivation().Grant(context.Background(), userRes, ent)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, []string{"reactivate u1", "add u1"}, calls)
+	assert.Equal(t, "u1", reactivatedUserID(t, grants[0]))
+
+	t.Run("emits the grant of a reactivated user who already is a member", func(t *testing.T) {
+		calls = nil
+		mockClient.GetGroupUsersFunc = func(ctx context.Context, groupID string, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+			return []client.User{{UserId: "u1"}}, "", nil, nil
+		}
+
+		grants, annos, err := newTestGroupBuilder(mockClient).withClosedUserReactivation().Grant(context.Background(), userRes, ent)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, []string{"reactivate u1"}, calls)
+		assert.Equal(t, "u1", reactivatedUserID(t, grants[0]))
+		assert.False(t, annos.Contains(&v2.GrantAlreadyExists{}))
+	})
+}
+
+// TestPermissionProfileBuilder_Grant_ReactivatesClosedUsers verifies closed users are reactivated before being moved, only when enabled.
+func TestPermissionProfileBuilder_Grant_ReactivatesClosedUsers(t *testing.T) {
+	newProfileClient := func() *mockPermissionProfileClient {
+		return &mockPermissionProfileClient{
+			profiles: readMockPermissionProfiles(t),
+			details: map[string]*client.UserDetail{
+				"u1": {UserID: "u1", UserStatus: "Closed", PermissionProfileId: "1002"},
+			},
+		}
+	}
+	principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u1"}}
+	ent := newTestPermissionProfileEntitlement(t, "1001", "DS Admin")
+
+	t.Run("leaves closed users alone by default", func(t *testing.T) {
+		profileClient := newProfileClient()
+		grants, _, err := newPermissionProfileBuilder(profileClient, "", nil).Grant(context.Background(), principal, ent)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, "Closed", profileClient.details["u1"].UserStatus)
+		assert.Empty(t, reactivatedUserID(t, grants[0]))
+	})
+
+	t.Run("reactivates closed users when enabled", func(t *testing.T) {
+		profileClient := newProfileClient()
+		grants, _, err := newPermissionProfileBuilder(profileClient, "", nil).withClosedUserReactivation().Grant(context.Background(), principal, ent)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, "Active", profileClient.details["u1"].UserStatus)
+		assert.Equal(t, "1001", profileClient.updates["u1"])
+		assert.Equal(t, "u1", reactivatedUserID(t, grants[0]))
+	})
+
+	t.Run("emits the grant of a reactivated user who already has the profile", func(t *testing.T) {
+		profileClient := newProfileClient()
+		assigned := newTestPermissionProfileEntitlement(t, "1002", "DocuSign Sender")
+		grants, annos, err := newPermissionProfileBuilder(profileClient, "", nil).withClosedUserReactivation().Grant(context.Background(), principal, assigned)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, "Active", profileClient.details["u1"].UserStatus)
+		// The only update is the reactivation, which leaves the profile alone.
+		assert.Equal(t, map[string]string{"u1": ""}, profileClient.updates)
+		assert.Equal(t, "u1", reactivatedUserID(t, grants[0]))
+		assert.False(t, annos.Contains(&v2.GrantAlreadyExists{}))
+	})
+}
@@ -5,6 +5,10 @@ import (
 )
 
 var (
+	accountResourceType = &v2.ResourceType{
+		Id:          "account",
+		DisplayName: "Account",
+	}
 	userResourceType = &v2.ResourceType{
 		Id:          "user",
 		DisplayName: "User",
@@ -19,4 +23,22 @@ var (
 		Id:          "permission",
 		DisplayName: "Permission",
 	}
+	permissionProfileResourceType = &v2.ResourceType{
+		Id:          "permission_profile",
+		DisplayName: "Permission Profile",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_ROLE},
+	}
+	cloudStorageProviderResourceType = &v2.ResourceType{
+		Id:          "cloud_storage_provider",
+		DisplayName: "Cloud Storage Provider",
+	}
+	signingGroupResourceType = &v2.ResourceType{
+		Id:          "signing_group",
+		DisplayName: "Signing Group",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_GROUP},
+	}
+	brandResourceType = &v2.ResourceType{
+		Id:          "brand",
+		DisplayName: "Brand",
+	}
 )
@@ -0,0 +1,318 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	"github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+// Entitlement value representing signing group membership.
+const (
+	entitlementSigningGroupMember = "member"
+)
+
+// SigningGroupClient defines the methods required for signing group API calls.
+type SigningGroupClient interface {
+	GetSigningGroups(ctx context.Context) ([]client.SigningGroup, annotations.Annotations, error)
+	GetSigningGroupUsers(ctx context.Context, signingGroupID string) ([]client.SigningGroupUser, annotations.Annotations, error)
+	GetUsersByEmail(ctx context.Context, email string) ([]client.User, annotations.Annotations, error)
+	GetUserDetails(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error)
+	AddSigningGroupUsers(ctx context.Context, signingGroupID string, users []client.SigningGroupUser) (*client.SigningGroupUsersResponse, annotations.Annotations, error)
+	RemoveSigningGroupUsers(ctx context.Context, signingGroupID string, users []client.SigningGroupUser) (*client.SigningGroupUsersResponse, annotations.Annotations, error)
+}
+
+// signingGroupBuilder syncs signing groups, the pools of people who can sign on behalf of a team, and provisions their members.
+// DocuSign identifies signing group members by email, so members are matched to account users by their email address.
+type signingGroupBuilder struct {
+	resourceType *v2.ResourceType
+	client       SigningGroupClient
+
+	mu      sync.Mutex
+	userIDs map[string]string
+}
+
+// ResourceType returns the Baton resource type handled by this builder.
+func (s *signingGroupBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return signingGroupResourceType
+}
+
+// List fetches the signing groups of the account and converts them to Baton resources.
+// Signing groups are listed once per sync, so the user IDs looked up by email during the previous sync are dropped here.
+func (s *signingGroupBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	s.mu.Lock()
+	s.userIDs = nil
+	s.mu.Unlock()
+
+	signingGroups, annos, err := s.client.GetSigningGroups(ctx)
+	if err != nil {
+		return nil, "", annos, fmt.Errorf("docusign-connector: failed to list signing groups: %w", err)
+	}
+
+	resources := make([]*v2.Resource, 0, len(signingGroups))
+	for _, signingGroup := range signingGroups {
+		signingGroupResource, err := parseIntoSigningGroupResource(signingGroup)
+		if err != nil {
+			return nil, "", annos, err
+		}
+		resources = append(resources, signingGroupResource)
+	}
+
+	return resources, "", annos, nil
+}
+
+// Entitlements returns a "member" entitlement for each signing group, grantable to users.
+func (s *signingGroupBuilder) Entitlements(ctx context.Context, signingGroupResource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	ent := entitlement.NewAssignmentEntitlement(
+		signingGroupResource,
+		entitlementSigningGroupMember,
+		entitlement.WithGrantableTo(userResourceType),
+		entitlement.WithDisplayName(fmt.Sprintf("Member of %s", signingGroupResource.DisplayName)),
+		entitlement.WithDescription(fmt.Sprintf("Can sign on behalf of the %s signing group", signingGroupResource.DisplayName)),
+	)
+	return []*v2.Entitlement{ent}, "", annotations.Annotations{}, nil
+}
+
+// Grants returns a "member" grant for every member of the signing group that matches an account user.
+// Members without a DocuSign user, who sign with their email address only, are logged and skipped.
+func (s *signingGroupBuilder) Grants(ctx context.Context, signingGroupResource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+	signingGroupID := signingGroupResource.Id.Resource
+
+	members, annos, err := s.client.GetSigningGroupUsers(ctx, signingGroupID)
+	if err != nil {
+		return nil, "", annos, fmt.Errorf("docusign-connector: failed to get users of signing group %s: %w", signingGroupID, err)
+	}
+
+	var grants []*v2.Grant
+	for _, member := range members {
+		userID, lookupAnnos, err := s.userIDForEmail(ctx, member.Email)
+		annos = append(annos, lookupAnnos...)
+		if err != nil {
+			return nil, "", annos, err
+		}
+		if userID == "" {
+			l.Debug("docusign-connector: signing group member is not an account user",
+				zap.String("signing_group_id", signingGroupID),
+				zap.String("email", member.Email),
+			)
+			continue
+		}
+		grants = append(grants, newSigningGroupMemberGrant(signingGroupResource, userID, member))
+	}
+
+	return grants, "", annos, nil
+}
+
+// Grant adds the user to the signing group under the user's email address.
+// A member with the same email, including one added before the user had a DocuSign account, is reported as already granted.
+func (s *signingGroupBuilder) Grant(ctx context.Context, principal *v2.Resource, ent *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	if principal.Id.ResourceType != userResourceType.Id {
+		return nil, nil, fmt.Errorf("docusign-connector: only users can be added to a signing group")
+	}
+
+	signingGroupID := ent.Resource.Id.Resource
+	userID := principal.Id.Resource
+
+	detail, annos, err := s.userDetail(ctx, userID)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	existing, memberAnnos, err := s.findMember(ctx, signingGroupID, detail.Email)
+	annos = append(annos, memberAnnos...)
+	if err != nil {
+		return nil, annos, err
+	}
+	if existing != nil {
+		annos.Update(&v2.GrantAlreadyExists{})
+		return nil, annos, nil
+	}
+
+	member := client.SigningGroupUser{UserName: detail.UserName, Email: detail.Email}
+	response, addAnnos, err := s.client.AddSigningGroupUsers(ctx, signingGroupID, []client.SigningGroupUser{member})
+	annos = append(annos, addAnnos...)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to add %s to signing group %s: %w", userID, signingGroupID, err)
+	}
+	if err := signingGroupUserError(response, detail.Email); err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to add %s to signing group %s: %w", userID, signingGroupID, err)
+	}
+
+	return []*v2.Grant{newSigningGroupMemberGrant(ent.Resource, userID, member)}, annos, nil
+}
+
+// Revoke removes the member with the user's email address from the signing group.
+// Users without a member record are reported as already revoked.
+func (s *signingGroupBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	if g.Principal.Id.ResourceType != userResourceType.Id {
+		return nil, fmt.Errorf("docusign-connector: only users can be removed from a signing group")
+	}
+
+	signingGroupID := g.Entitlement.Resource.Id.Resource
+	userID := g.Principal.Id.Resource
+
+	detail, annos, err := s.userDetail(ctx, userID)
+	if err != nil {
+		return annos, err
+	}
+
+	member, memberAnnos, err := s.findMember(ctx, signingGroupID, detail.Email)
+	annos = append(annos, memberAnnos...)
+	if err != nil {
+		return annos, err
+	}
+	if member == nil {
+		annos.Update(&v2.GrantAlreadyRevoked{})
+		return annos, nil
+	}
+
+	// The member is removed as DocuSign recorded it, as its email may differ from the user's in case.
+	response, removeAnnos, err := s.client.RemoveSigningGroupUsers(ctx, signingGroupID, []client.SigningGroupUser{{UserName: member.UserName, Email: member.Email}})
+	annos = append(annos, removeAnnos...)
+	if err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to remove %s from signing group %s: %w", userID, signingGroupID, err)
+	}
+	if err := signingGroupUserError(response, member.Email); err != nil {
+		return annos, fmt.Errorf("docusign-connector: failed to remove %s from signing group %s: %w", userID, signingGroupID, err)
+	}
+
+	return annos, nil
+}
+
+// userDetail fetches the user being provisioned, which must have an email address to be a signing group member.
+func (s *signingGroupBuilder) userDetail(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+	detail, annos, err := s.client.GetUserDetails(ctx, userID)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to get user %s: %w", userID, err)
+	}
+	if detail.Email == "" {
+		return nil, annos, fmt.Errorf("docusign-connector: user %s has no email address", userID)
+	}
+	return detail, annos, nil
+}
+
+// findMember returns the member of the signing group with the email address, or nil when there is none.
+func (s *signingGroupBuilder) findMember(ctx context.Context, signingGroupID, email string) (*client.SigningGroupUser, annotations.Annotations, error) {
+	members, annos, err := s.client.GetSigningGroupUsers(ctx, signingGroupID)
+	if err != nil {
+		return nil, annos, fmt.Errorf("docusign-connector: failed to get users of signing group %s: %w", signingGroupID, err)
+	}
+	for _, member := range members {
+		if strings.EqualFold(member.Email, email) {
+			return &member, annos, nil
+		}
+	}
+	return nil, annos, nil
+}
+
+// userIDForEmail returns the ID of the account user with the email address, or "" when there is none.
+// Lookups are cached until List starts the next sync, so each address is only looked up once per sync.
+// The lock is not held during the lookup, so concurrent lookups of a new address may both reach the API.
+func (s *signingGroupBuilder) userIDForEmail(ctx context.Context, email string) (string, annotations.Annotations, error) {
+	key := strings.ToLower(email)
+
+	s.mu.Lock()
+	userID, ok := s.userIDs[key]
+	s.mu.Unlock()
+	if ok {
+		return userID, nil, nil
+	}
+
+	users, annos, err := s.client.GetUsersByEmail(ctx, email)
+	if err != nil {
+		return "", annos, fmt.Errorf("docusign-connector: failed to look up users with email %s: %w", email, err)
+	}
+
+	// An address can belong to several users, such as a closed user and its replacement, so active users win.
+	userID = ""
+	for _, user := range users {
+		if !strings.EqualFold(user.Email, email) {
+			continue
+		}
+		if strings.EqualFold(user.UserStatus, "active") {
+			userID = user.UserId
+			break
+		}
+		if userID == "" {
+			userID = user.UserId
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.userIDs == nil {
+		s.userIDs = make(map[string]string)
+	}
+	s.userIDs[key] = userID
+	return userID, annos, nil
+}
+
+// newSigningGroupBuilder constructs a signingGroupBuilder with the provided API client.
+func newSigningGroupBuilder(client SigningGroupClient) *signingGroupBuilder {
+	return &signingGroupBuilder{
+		resourceType: signingGroupResourceType,
+		client:       client,
+	}
+}
+
+// parseIntoSigningGroupResource maps a client.SigningGroup to a Baton v2.Resource.
+func parseIntoSigningGroupResource(signingGroup client.SigningGroup) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"group_name": signingGroup.GroupName,
+		"group_type": signingGroup.GroupType,
+		"email":      signingGroup.GroupEmail,
+		"created":    signingGroup.Created,
+		"modified":   signingGroup.Modified,
+	}
+
+	signingGroupResource, err := resource.NewGroupResource(
+		signingGroup.GroupName,
+		signingGroupResourceType,
+		signingGroup.SigningGroupId,
+		[]resource.GroupTraitOption{resource.WithGroupProfile(profile)},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing group resource: %w", err)
+	}
+	return signingGroupResource, nil
+}
+
+// signingGroupUserError returns the error DocuSign reported for the member with the email address, if any.
+func signingGroupUserError(response *client.SigningGroupUsersResponse, email string) error {
+	if response == nil {
+		return nil
+	}
+	for _, user := range response.Users {
+		if strings.EqualFold(user.Email, email) && user.ErrorDetails != nil {
+			return fmt.Errorf("%s: %s", user.ErrorDetails.ErrorCode, user.ErrorDetails.Message)
+		}
+	}
+	return nil
+}
+
+// newSigningGroupMemberGrant builds the grant of a signing group's "member" entitlement to a user.
+func newSigningGroupMemberGrant(signingGroupResource *v2.Resource, userID string, member client.SigningGroupUser) *v2.Grant {
+	return grant.NewGrant(
+		signingGroupResource,
+		entitlementSigningGroupMember,
+		makeUserSubjectID(userID),
+		grant.WithGrantMetadata(map[string]interface{}{
+			"signing_group_id":   signingGroupResource.Id.Resource,
+			"signing_group_name": signingGroupResource.DisplayName,
+			"user_id":            userID,
+			"username":           member.UserName,
+			"email":              member.Email,
+		}),
+	)
+}
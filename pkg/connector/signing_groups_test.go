@@ -0,0 +1,190 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/conductorone/baton-docusign/pkg/client"
+	"github.com/conductorone/baton-docusign/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSigningGroupClient implements SigningGroupClient from the mock responses, counts email lookups and records member updates.
+type mockSigningGroupClient struct {
+	users        []client.User
+	emailLookups int
+	added        []client.SigningGroupUser
+	removed      []client.SigningGroupUser
+}
+
+func (m *mockSigningGroupClient) GetSigningGroups(ctx context.Context) ([]client.SigningGroup, annotations.Annotations, error) {
+	var response client.SigningGroupsResponse
+	if err := json.Unmarshal([]byte(test.ReadFile("signing_groups.json")), &response); err != nil {
+		return nil, nil, err
+	}
+	return response.Groups, nil, nil
+}
+
+func (m *mockSigningGroupClient) GetSigningGroupUsers(ctx context.Context, signingGroupID string) ([]client.SigningGroupUser, annotations.Annotations, error) {
+	var response client.SigningGroupUsersResponse
+	if err := json.Unmarshal([]byte(test.ReadFile("signing_group_users.json")), &response); err != nil {
+		return nil, nil, err
+	}
+	return response.Users, nil, nil
+}
+
+func (m *mockSigningGroupClient) GetUsersByEmail(ctx context.Context, email string) ([]client.User, annotations.Annotations, error) {
+	m.emailLookups++
+	var users []client.User
+	for _, user := range m.users {
+		if strings.EqualFold(user.Email, email) {
+			users = append(users, user)
+		}
+	}
+	return users, nil, nil
+}
+
+func (m *mockSigningGroupClient) GetUserDetails(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+	for _, user := range m.users {
+		if user.UserId == userID {
+			return &client.UserDetail{UserID: user.UserId, UserName: user.UserName, Email: user.Email}, nil, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("user %s not found", userID)
+}
+
+func (m *mockSigningGroupClient) AddSigningGroupUsers(ctx context.Context, signingGroupID string, users []client.SigningGroupUser) (*client.SigningGroupUsersResponse, annotations.Annotations, error) {
+	m.added = append(m.added, users...)
+	return &client.SigningGroupUsersResponse{Users: users}, nil, nil
+}
+
+func (m *mockSigningGroupClient) RemoveSigningGroupUsers(ctx context.Context, signingGroupID string, users []client.SigningGroupUser) (*client.SigningGroupUsersResponse, annotations.Annotations, error) {
+	m.removed = append(m.removed, users...)
+	return &client.SigningGroupUsersResponse{Users: users}, nil, nil
+}
+
+var testSigningGroupUsers = []client.User{
+	{UserId: "u0", Email: "alice@example.com", UserStatus: "Closed"},
+	{UserId: "u1", UserName: "Alice Smith", Email: "Alice@example.com", UserStatus: "Active"},
+	{UserId: "u2", UserName: "Bob Jones", Email: "bob@example.com", UserStatus: "Active"},
+}
+
+// TestSigningGroupBuilder_List verifies signing groups are listed with their email and type.
+func TestSigningGroupBuilder_List(t *testing.T) {
+	builder := newSigningGroupBuilder(&mockSigningGroupClient{})
+
+	resources, nextToken, _, err := builder.List(context.Background(), nil, &pagination.Token{})
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Empty(t, nextToken)
+	assert.Equal(t, "Legal Signatories", resources[0].DisplayName)
+
+	groupTrait, err := resource.GetGroupTrait(resources[0])
+	require.NoError(t, err)
+	profile := groupTrait.GetProfile().AsMap()
+	assert.Equal(t, "legal-signers@example.com", profile["email"])
+	assert.Equal(t, "sharedSigningGroup", profile["group_type"])
+
+	ents, _, _, err := builder.Entitlements(context.Background(), resources[0], nil)
+	require.NoError(t, err)
+	require.Len(t, ents, 1)
+	assert.Equal(t, entitlementSigningGroupMember, ents[0].Slug)
+}
+
+// TestSigningGroupBuilder_Grants verifies members are matched to active account users by email,
+// email-only members are skipped, and each address is looked up once per sync.
+func TestSigningGroupBuilder_Grants(t *testing.T) {
+	signingGroupClient := &mockSigningGroupClient{users: testSigningGroupUsers}
+	builder := newSigningGroupBuilder(signingGroupClient)
+
+	resources, _, _, err := builder.List(context.Background(), nil, &pagination.Token{})
+	require.NoError(t, err)
+
+	for _, signingGroupResource := range resources {
+		grants, _, _, err := builder.Grants(context.Background(), signingGroupResource, &pagination.Token{})
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, "u1", grants[0].Principal.Id.Resource)
+		assert.Equal(t, signingGroupResource.Id.Resource, grants[0].Entitlement.Resource.Id.Resource)
+
+		metadata := &v2.GrantMetadata{}
+		annos := annotations.Annotations(grants[0].Annotations)
+		_, err = annos.Pick(metadata)
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", metadata.Metadata.AsMap()["email"])
+	}
+
+	assert.Equal(t, 2, signingGroupClient.emailLookups)
+
+	t.Run("looks addresses up again on the next sync", func(t *testing.T) {
+		resources, _, _, err := builder.List(context.Background(), nil, &pagination.Token{})
+		require.NoError(t, err)
+		_, _, _, err = builder.Grants(context.Background(), resources[0], &pagination.Token{})
+		require.NoError(t, err)
+		assert.Greater(t, signingGroupClient.emailLookups, 2)
+	})
+}
+
+// TestSigningGroupBuilder_Grant verifies users are added under their email address, and a member
+// with the same email, such as an email-only member, counts as already granted.
+func TestSigningGroupBuilder_Grant(t *testing.T) {
+	// The outside counsel joined the account after being added to the signing group by email.
+	users := append([]client.User{{UserId: "u3", UserName: "Outside Counsel", Email: "counsel@lawfirm.example", UserStatus: "Active"}}, testSigningGroupUsers...)
+	signingGroupClient := &mockSigningGroupClient{users: users}
+	builder := newSigningGroupBuilder(signingGroupClient)
+	ent := &v2.Entitlement{Resource: &v2.Resource{Id: &v2.ResourceId{ResourceType: signingGroupResourceType.Id, Resource: "sg1"}, DisplayName: "Legal Signatories"}}
+
+	bob := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u2"}}
+	grants, _, err := builder.Grant(context.Background(), bob, ent)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, "u2", grants[0].Principal.Id.Resource)
+	assert.Equal(t, []client.SigningGroupUser{{UserName: "Bob Jones", Email: "bob@example.com"}}, signingGroupClient.added)
+
+	counsel := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: "u3"}}
+	grants, annos, err := builder.Grant(context.Background(), counsel, ent)
+	require.NoError(t, err)
+	assert.Empty(t, grants)
+	assert.True(t, annos.Contains(&v2.GrantAlreadyExists{}))
+	assert.Len(t, signingGroupClient.added, 1)
+
+	groupRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: groupResourceType.Id, Resource: "g1"}}
+	_, _, err = builder.Grant(context.Background(), groupRes, ent)
+	require.Error(t, err)
+}
+
+// TestSigningGroupBuilder_Revoke verifies the member is removed as DocuSign recorded it and revoking is idempotent.
+func TestSigningGroupBuilder_Revoke(t *testing.T) {
+	signingGroupClient := &mockSigningGroupClient{users: testSigningGroupUsers}
+	builder := newSigningGroupBuilder(signingGroupClient)
+	signingGroupRes := &v2.Resource{Id: &v2.ResourceId{ResourceType: signingGroupResourceType.Id, Resource: "sg1"}, DisplayName: "Legal Signatories"}
+
+	alice := newSigningGroupMemberGrant(signingGroupRes, "u1", client.SigningGroupUser{Email: "Alice@example.com"})
+	_, err := builder.Revoke(context.Background(), alice)
+	require.NoError(t, err)
+	assert.Equal(t, []client.SigningGroupUser{{UserName: "Alice Smith", Email: "alice@example.com"}}, signingGroupClient.removed)
+
+	bob := newSigningGroupMemberGrant(signingGroupRes, "u2", client.SigningGroupUser{Email: "bob@example.com"})
+	annos, err := builder.Revoke(context.Background(), bob)
+	require.NoError(t, err)
+	assert.True(t, annos.Contains(&v2.GrantAlreadyRevoked{}))
+	assert.Len(t, signingGroupClient.removed, 1)
+}
+
+// TestSigningGroupBuilder_GrantError verifies member errors reported by DocuSign fail the grant.
+func TestSigningGroupBuilder_GrantError(t *testing.T) {
+	response := &client.SigningGroupUsersResponse{Users: []client.SigningGroupUser{{
+		Email:        "bob@example.com",
+		ErrorDetails: &client.ErrorDetails{ErrorCode: "INVALID_EMAIL_ADDRESS_FOR_RECIPIENT", Message: "The email address for the recipient is invalid."},
+	}}}
+	assert.ErrorContains(t, signingGroupUserError(response, "Bob@example.com"), "INVALID_EMAIL_ADDRESS_FOR_RECIPIENT")
+	assert.NoError(t, signingGroupUserError(response, "alice@example.com"))
+}
@@ -3,6 +3,10 @@ package connector
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/conductorone/baton-docusign/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
@@ -10,6 +14,8 @@ import (
 	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	"github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
 )
 
 // UserClient defines the interface for DocuSign user API operations.
@@ -17,6 +23,30 @@ type UserClient interface {
 	GetUsers(ctx context.Context, options client.PageOptions) ([]client.User, string, annotations.Annotations, error)
 	GetUserDetails(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error)
 	CreateUsers(ctx context.Context, request client.CreateUsersRequest) (*client.UserCreationResponse, annotations.Annotations, error)
+	GetUserCloudStorage(ctx context.Context, userID string) ([]client.CloudStorageProvider, annotations.Annotations, error)
+	GetPermissionProfiles(ctx context.Context) ([]client.PermissionProfile, annotations.Annotations, error)
+}
+
+// OrganizationClient defines the DocuSign Admin API operations used to enrich users.
+type OrganizationClient interface {
+	GetOrganizationUserProfile(ctx context.Context, email string) (*client.OrganizationUser, annotations.Annotations, error)
+	GetIdentityProviders(ctx context.Context) ([]client.IdentityProvider, annotations.Annotations, error)
+}
+
+// SSO status values reported in the user profile.
+const (
+	ssoStatusSSO               = "sso"
+	ssoStatusPassword          = "password"
+	ssoStatusNotInOrganization = "not_in_organization"
+)
+
+// userEnrichers groups the optional lookups that add details to synced users.
+// A nil field disables the corresponding enrichment.
+type userEnrichers struct {
+	orgClient     OrganizationClient
+	dormancy      *dormancyChecker
+	envelopeUsage *envelopeUsageCounter
+	domains       *domainClassifier
 }
 
 // userBuilder handles user resource management and permission assignments.
@@ -24,6 +54,13 @@ type userBuilder struct {
 	resourceType      *v2.ResourceType
 	client            UserClient
 	permissionBuilder *permissionBuilder
+	groupBuilder      *groupBuilder
+	catalog           *permissionCatalog
+	userEnrichers
+
+	mu                sync.Mutex
+	identityProviders map[string]string
+	profileSettings   map[string]map[string]interface{}
 }
 
 // ResourceType returns the Baton resource type handled by this builder.
@@ -33,19 +70,26 @@ func (b *userBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
 
 // List retrieves all users from DocuSign API and converts them to Baton resources.
 // Uses pagination to handle large datasets efficiently.
+// Users holding a critical permission get a critical_permissions profile field listing them.
 func (b *userBuilder) List(
 	ctx context.Context,
 	parentResourceID *v2.ResourceId,
 	pToken *pagination.Token,
 ) ([]*v2.Resource, string, annotations.Annotations, error) {
 	var resources []*v2.Resource
+	// Each sync starts with the first page of users, so the lookups cached by the previous sync are dropped.
+	if pToken.Token == "" {
+		b.resetCaches()
+	}
 	bag, pageToken, err := parsePageToken(pToken.Token, &v2.ResourceId{ResourceType: userResourceType.Id})
 	if err != nil {
 		return nil, "", nil, err
 	}
+	// The user settings are only needed here, for the critical_permissions profile field.
 	users, nextPageToken, annotation, err := b.client.GetUsers(ctx, client.PageOptions{
-		PageSize:  pToken.Size,
-		PageToken: pageToken,
+		PageSize:       pToken.Size,
+		PageToken:      pageToken,
+		AdditionalInfo: true,
 	})
 	if err != nil {
 		return nil, "", nil, err
@@ -53,7 +97,52 @@ func (b *userBuilder) List(
 
 	for _, user := range users {
 		userCopy := user
-		userResource, err := parseIntoUserResource(&userCopy)
+		extraProfile := map[string]interface{}{}
+		var traitOptions []resource.UserTraitOption
+		if b.orgClient != nil && user.Email != "" {
+			ssoProfile, ssoOption, ssoAnnos, err := b.ssoStatus(ctx, user.Email)
+			if err != nil {
+				return nil, "", nil, err
+			}
+			annotation = append(annotation, ssoAnnos...)
+			maps.Copy(extraProfile, ssoProfile)
+			if ssoOption != nil {
+				traitOptions = append(traitOptions, ssoOption)
+			}
+		}
+
+		if b.dormancy != nil {
+			activity, activityAnnos, err := b.dormancy.Check(ctx, &userCopy)
+			if err != nil {
+				return nil, "", nil, err
+			}
+			annotation = append(annotation, activityAnnos...)
+			maps.Copy(extraProfile, activity.Profile())
+			if details := activity.StatusDetails(b.dormancy.days); details != "" {
+				traitOptions = append(traitOptions, resource.WithDetailedStatus(userTraitStatus(user.UserStatus), details))
+			}
+		}
+
+		if b.envelopeUsage != nil {
+			usage, usageAnnos, err := b.envelopeUsage.Count(ctx, user.UserId)
+			if err != nil {
+				return nil, "", nil, err
+			}
+			annotation = append(annotation, usageAnnos...)
+			maps.Copy(extraProfile, usage.Profile(b.envelopeUsage.days))
+		}
+
+		if b.domains != nil {
+			match, domainAnnos := b.domains.Classify(ctx, user.Email)
+			annotation = append(annotation, domainAnnos...)
+			maps.Copy(extraProfile, match.Profile())
+		}
+
+		if critical := catalogOrDefault(b.catalog).Critical(user.UserSettings); len(critical) > 0 {
+			extraProfile["critical_permissions"] = strings.Join(critical, ",")
+		}
+
+		userResource, err := parseIntoUserResource(&userCopy, extraProfile, traitOptions...)
 		if err != nil {
 			return nil, "", nil, err
 		}
@@ -78,16 +167,12 @@ func (b *userBuilder) Entitlements(ctx context.Context, resource *v2.Resource, _
 }
 
 // Grants assigns permissions to users based on their DocuSign settings.
-// Uses permissionBuilder to ensure all grants reference the central permission resource.
+// Uses permissionBuilder to ensure all grants reference the permission resources of the configured mode.
+// Permission profile assignments and cloud storage connections are emitted here as well since they are read per user.
 func (b *userBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
 	annos := annotations.Annotations{}
 	userId := resource.Id.Resource
 
-	permissionResource, err := b.permissionBuilder.GetPermissionResource(ctx)
-	if err != nil {
-		return nil, "", nil, fmt.Errorf("failed to get permission resource: %w", err)
-	}
-
 	var grants []*v2.Grant
 
 	detail, annotation, err := b.client.GetUserDetails(ctx, userId)
@@ -99,11 +184,58 @@ func (b *userBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken
 		annos.Append(annon)
 	}
 
-	userGrants, err := createUserGrants(permissionResource, detail)
+	var grantMetadata map[string]interface{}
+	if b.domains != nil {
+		match, domainAnnos := b.domains.Classify(ctx, detail.Email)
+		for _, annon := range domainAnnos {
+			annos.Append(annon)
+		}
+		if !match.Undetermined {
+			grantMetadata = map[string]interface{}{"external": match.External}
+		}
+	}
+
+	profileSettings, profileAnnos, err := b.getProfileSettings(ctx)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	for _, annon := range profileAnnos {
+		annos.Append(annon)
+	}
+
+	settingsMap := detail.RawUserSettings
+	if settingsMap == nil {
+		settingsMap, err = parseUserSettings(detail.UserSettings)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to create grants for %s: %w", userId, err)
+		}
+	}
+
+	catalog := catalogOrDefault(b.catalog)
+	catalog.ReportUnknownSettings(ctx, settingsMap)
+	grants = append(grants, createUserGrants(b.permissionBuilder, detail, settingsMap, profileSettings[detail.PermissionProfileId], grantMetadata)...)
+
+	if profileGrant := createPermissionProfileGrant(detail); profileGrant != nil {
+		grants = append(grants, profileGrant)
+	}
+
+	// Cloud storage connections are supplementary, so a failed lookup skips them rather than the user's other grants.
+	cloudStorage, cloudStorageAnnos, err := b.client.GetUserCloudStorage(ctx, userId)
+	for _, annon := range cloudStorageAnnos {
+		annos.Append(annon)
+	}
 	if err != nil {
-		return nil, "", nil, fmt.Errorf("failed to create grants for %s: %w", userId, err)
+		ctxzap.Extract(ctx).Warn("docusign-connector: failed to fetch cloud storage, skipping its grants",
+			zap.String("user_id", userId),
+			zap.Error(err),
+		)
+	} else {
+		grants = append(grants, createCloudStorageGrants(detail, cloudStorage)...)
+	}
+
+	if b.groupBuilder != nil && b.groupBuilder.userCentric {
+		grants = append(grants, b.groupBuilder.userMembershipGrants(detail)...)
 	}
-	grants = append(grants, userGrants...)
 
 	return grants, "", annos, nil
 }
@@ -172,7 +304,7 @@ func (b *userBuilder) CreateAccount(
 		UserName:   created.UserName,
 		Email:      created.Email,
 		UserStatus: created.UserStatus,
-	})
+	}, nil)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -182,26 +314,141 @@ func (b *userBuilder) CreateAccount(
 	}, nil, annos, nil
 }
 
+// ssoStatus looks up the user's linked identities in the DocuSign Admin API and returns the
+// profile fields and SSO trait option describing how the user logs in.
+// Users that are not part of the organization are reported in the profile instead of failing the sync.
+func (b *userBuilder) ssoStatus(ctx context.Context, email string) (map[string]interface{}, resource.UserTraitOption, annotations.Annotations, error) {
+	orgUser, annos, err := b.orgClient.GetOrganizationUserProfile(ctx, email)
+	if err != nil {
+		return nil, nil, annos, fmt.Errorf("failed to fetch organization profile for %s: %w", email, err)
+	}
+
+	if orgUser == nil {
+		return map[string]interface{}{
+			"organization_member": false,
+			"sso_status":          ssoStatusNotInOrganization,
+		}, nil, annos, nil
+	}
+
+	providers, providerAnnos, err := b.getIdentityProviders(ctx)
+	if err != nil {
+		return nil, nil, annos, err
+	}
+	annos = append(annos, providerAnnos...)
+
+	var providerNames []string
+	for _, identity := range orgUser.Identities {
+		name, ok := providers[identity.ProviderID]
+		if !ok {
+			name = identity.ProviderID
+		}
+		if name != "" && !slices.Contains(providerNames, name) {
+			providerNames = append(providerNames, name)
+		}
+	}
+
+	ssoEnabled := len(providerNames) > 0
+	profile := map[string]interface{}{
+		"organization_member": true,
+		"sso_status":          ssoStatusPassword,
+	}
+	if ssoEnabled {
+		profile["sso_status"] = ssoStatusSSO
+		profile["identity_provider"] = strings.Join(providerNames, ", ")
+	}
+
+	return profile, resource.WithSSOStatus(&v2.UserTrait_SSOStatus{SsoEnabled: ssoEnabled}), annos, nil
+}
+
+// resetCaches drops the identity providers, permission profile settings and domains loaded during the previous sync,
+// and the unknown settings it reported.
+func (b *userBuilder) resetCaches() {
+	b.mu.Lock()
+	b.identityProviders = nil
+	b.profileSettings = nil
+	b.mu.Unlock()
+
+	if b.domains != nil {
+		b.domains.reset()
+	}
+	catalogOrDefault(b.catalog).resetReported()
+}
+
+// getIdentityProviders returns the organization's identity providers keyed by ID, loading them once per sync.
+func (b *userBuilder) getIdentityProviders(ctx context.Context) (map[string]string, annotations.Annotations, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.identityProviders != nil {
+		return b.identityProviders, nil, nil
+	}
+
+	providers, annos, err := b.orgClient.GetIdentityProviders(ctx)
+	if err != nil {
+		return nil, annos, fmt.Errorf("failed to fetch identity providers: %w", err)
+	}
+
+	b.identityProviders = make(map[string]string, len(providers))
+	for _, provider := range providers {
+		b.identityProviders[provider.ID] = provider.FriendlyName
+	}
+
+	return b.identityProviders, annos, nil
+}
+
+// getProfileSettings returns the settings of every permission profile keyed by profile ID, loading them once per sync.
+func (b *userBuilder) getProfileSettings(ctx context.Context) (map[string]map[string]interface{}, annotations.Annotations, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.profileSettings != nil {
+		return b.profileSettings, nil, nil
+	}
+
+	profiles, annos, err := b.client.GetPermissionProfiles(ctx)
+	if err != nil {
+		return nil, annos, fmt.Errorf("failed to fetch permission profiles: %w", err)
+	}
+
+	b.profileSettings = make(map[string]map[string]interface{}, len(profiles))
+	for _, profile := range profiles {
+		if profile.Settings != nil {
+			b.profileSettings[profile.PermissionProfileId] = profile.Settings
+		}
+	}
+
+	return b.profileSettings, annos, nil
+}
+
 // newUserBuilder constructs a userBuilder with the provided API client.
-func newUserBuilder(client *client.Client, pb *permissionBuilder) *userBuilder {
+// enrichers holds the optional lookups used to add details to each user.
+func newUserBuilder(client *client.Client, pb *permissionBuilder, gb *groupBuilder, enrichers userEnrichers) *userBuilder {
 	return &userBuilder{
 		resourceType:      userResourceType,
 		client:            client,
 		permissionBuilder: pb,
+		groupBuilder:      gb,
+		catalog:           pb.catalog,
+		userEnrichers:     enrichers,
 	}
 }
 
-// parseIntoUserResource maps a client.User object into a Baton v2.Resource.
-func parseIntoUserResource(user *client.User) (*v2.Resource, error) {
-	var userStatus v2.UserTrait_Status_Status
-	switch user.UserStatus {
+// userTraitStatus maps a DocuSign user status to a Baton user trait status.
+func userTraitStatus(status string) v2.UserTrait_Status_Status {
+	switch status {
 	case "Active":
-		userStatus = v2.UserTrait_Status_STATUS_ENABLED
+		return v2.UserTrait_Status_STATUS_ENABLED
 	case "Disabled", "Closed", "ActivationRequired", "ActivationSent":
-		userStatus = v2.UserTrait_Status_STATUS_DISABLED
+		return v2.UserTrait_Status_STATUS_DISABLED
 	default:
-		userStatus = v2.UserTrait_Status_STATUS_UNSPECIFIED
+		return v2.UserTrait_Status_STATUS_UNSPECIFIED
 	}
+}
+
+// parseIntoUserResource maps a client.User object into a Baton v2.Resource.
+// extraProfile is merged into the user profile and opts are applied after the default trait options.
+func parseIntoUserResource(user *client.User, extraProfile map[string]interface{}, opts ...resource.UserTraitOption) (*v2.Resource, error) {
+	userStatus := userTraitStatus(user.UserStatus)
 
 	profile := map[string]interface{}{
 		"userName":   user.UserName,
@@ -210,12 +457,19 @@ func parseIntoUserResource(user *client.User) (*v2.Resource, error) {
 		"permission": user.Permission,
 		"status":     user.UserStatus,
 	}
+	for key, value := range extraProfile {
+		profile[key] = value
+	}
 
 	userTraits := []resource.UserTraitOption{
 		resource.WithUserProfile(profile),
 		resource.WithStatus(userStatus),
 		resource.WithUserLogin(user.UserName),
 	}
+	if lastLogin, err := client.ParseTime(user.LastLogin); err == nil && lastLogin != nil {
+		userTraits = append(userTraits, resource.WithLastLogin(*lastLogin))
+	}
+	userTraits = append(userTraits, opts...)
 
 	return resource.NewUserResource(
 		user.UserName,
@@ -11,14 +11,17 @@ import (
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/resource"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 // mockClient implements the UserClient interface with the minimum necessary.
 type mockClient struct {
-	getUsersFunc       func(ctx context.Context, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error)
-	getUserDetailsFunc func(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error)
+	getUsersFunc              func(ctx context.Context, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error)
+	getUserDetailsFunc        func(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error)
+	getUserCloudStorageFunc   func(ctx context.Context, userID string) ([]client.CloudStorageProvider, annotations.Annotations, error)
+	getPermissionProfilesFunc func(ctx context.Context) ([]client.PermissionProfile, annotations.Annotations, error)
 }
 
 func (m *mockClient) GetUsers(ctx context.Context, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
@@ -39,6 +42,20 @@ func (m *mockClient) CreateUsers(ctx context.Context, request client.CreateUsers
 	return nil, nil, errors.New("not implemented")
 }
 
+func (m *mockClient) GetUserCloudStorage(ctx context.Context, userID string) ([]client.CloudStorageProvider, annotations.Annotations, error) {
+	if m.getUserCloudStorageFunc != nil {
+		return m.getUserCloudStorageFunc(ctx, userID)
+	}
+	return nil, nil, nil
+}
+
+func (m *mockClient) GetPermissionProfiles(ctx context.Context) ([]client.PermissionProfile, annotations.Annotations, error) {
+	if m.getPermissionProfilesFunc != nil {
+		return m.getPermissionProfilesFunc(ctx)
+	}
+	return nil, nil, nil
+}
+
 // TestUserBuilder_List tests the List method of userBuilder with different scenarios:.
 // - When users exist in the response.
 // - When the user list is empty.
@@ -115,7 +132,7 @@ func TestParseIntoUserResource(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseIntoUserResource(tt.user)
+			got, err := parseIntoUserResource(tt.user, nil)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -141,8 +158,9 @@ func TestUserBuilder_Grants(t *testing.T) {
 	}
 
 	builder := &userBuilder{
-		resourceType: userResourceType,
-		client:       mockClient,
+		resourceType:      userResourceType,
+		client:            mockClient,
+		permissionBuilder: newPermissionBuilder(nil, nil, false),
 	}
 
 	ctx := context.Background()
@@ -157,3 +175,141 @@ func TestUserBuilder_Grants(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, grants)
 }
+
+// mockOrganizationClient implements the OrganizationClient interface for SSO lookups.
+type mockOrganizationClient struct {
+	profiles  map[string]*client.OrganizationUser
+	providers []client.IdentityProvider
+}
+
+func (m *mockOrganizationClient) GetOrganizationUserProfile(ctx context.Context, email string) (*client.OrganizationUser, annotations.Annotations, error) {
+	return m.profiles[email], nil, nil
+}
+
+func (m *mockOrganizationClient) GetIdentityProviders(ctx context.Context) ([]client.IdentityProvider, annotations.Annotations, error) {
+	return m.providers, nil, nil
+}
+
+// TestUserBuilder_List_SSOStatus verifies that users are enriched with SSO status from the Admin API.
+// Users missing from the organization are reported in the profile rather than failing the sync.
+func TestUserBuilder_List_SSOStatus(t *testing.T) {
+	mockClient := &mockClient{
+		getUsersFunc: func(ctx context.Context, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+			return []client.User{
+				{UserId: "1", UserName: "sso", Email: "sso@example.com", UserStatus: "Active"},
+				{UserId: "2", UserName: "password", Email: "password@example.com", UserStatus: "Active"},
+				{UserId: "3", UserName: "outsider", Email: "outsider@example.com", UserStatus: "Active"},
+			}, "", nil, nil
+		},
+	}
+	orgClient := &mockOrganizationClient{
+		profiles: map[string]*client.OrganizationUser{
+			"sso@example.com": {
+				ID:         "org-1",
+				Identities: []client.OrganizationIdentity{{ID: "identity-1", ProviderID: "idp-1"}},
+			},
+			"password@example.com": {ID: "org-2"},
+		},
+		providers: []client.IdentityProvider{{ID: "idp-1", FriendlyName: "Okta"}},
+	}
+
+	builder := &userBuilder{
+		resourceType:  userResourceType,
+		client:        mockClient,
+		userEnrichers: userEnrichers{orgClient: orgClient},
+	}
+
+	resources, _, _, err := builder.List(context.Background(), nil, &pagination.Token{})
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+
+	ssoTrait, err := resource.GetUserTrait(resources[0])
+	require.NoError(t, err)
+	assert.True(t, ssoTrait.GetSsoStatus().GetSsoEnabled())
+	assert.Equal(t, ssoStatusSSO, ssoTrait.GetProfile().AsMap()["sso_status"])
+	assert.Equal(t, "Okta", ssoTrait.GetProfile().AsMap()["identity_provider"])
+
+	passwordTrait, err := resource.GetUserTrait(resources[1])
+	require.NoError(t, err)
+	require.NotNil(t, passwordTrait.GetSsoStatus())
+	assert.False(t, passwordTrait.GetSsoStatus().GetSsoEnabled())
+	assert.Equal(t, ssoStatusPassword, passwordTrait.GetProfile().AsMap()["sso_status"])
+
+	outsiderTrait, err := resource.GetUserTrait(resources[2])
+	require.NoError(t, err)
+	assert.Nil(t, outsiderTrait.GetSsoStatus())
+	assert.Equal(t, ssoStatusNotInOrganization, outsiderTrait.GetProfile().AsMap()["sso_status"])
+	assert.Equal(t, false, outsiderTrait.GetProfile().AsMap()["organization_member"])
+}
+
+// TestUserBuilder_List_ResetsCachesEachSync verifies identity providers cached by one sync are reloaded by the next.
+func TestUserBuilder_List_ResetsCachesEachSync(t *testing.T) {
+	mockClient := &mockClient{
+		getUsersFunc: func(ctx context.Context, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+			return []client.User{{UserId: "1", UserName: "sso", Email: "sso@example.com", UserStatus: "Active"}}, "", nil, nil
+		},
+	}
+	orgClient := &mockOrganizationClient{
+		profiles: map[string]*client.OrganizationUser{
+			"sso@example.com": {ID: "org-1", Identities: []client.OrganizationIdentity{{ID: "identity-1", ProviderID: "idp-1"}}},
+		},
+		providers: []client.IdentityProvider{{ID: "idp-1", FriendlyName: "Okta"}},
+	}
+	builder := &userBuilder{
+		resourceType:  userResourceType,
+		client:        mockClient,
+		userEnrichers: userEnrichers{orgClient: orgClient},
+	}
+
+	resources, _, _, err := builder.List(context.Background(), nil, &pagination.Token{})
+	require.NoError(t, err)
+	userTrait, err := resource.GetUserTrait(resources[0])
+	require.NoError(t, err)
+	assert.Equal(t, "Okta", userTrait.GetProfile().AsMap()["identity_provider"])
+
+	orgClient.providers = []client.IdentityProvider{{ID: "idp-1", FriendlyName: "Entra ID"}}
+	resources, _, _, err = builder.List(context.Background(), nil, &pagination.Token{})
+	require.NoError(t, err)
+	userTrait, err = resource.GetUserTrait(resources[0])
+	require.NoError(t, err)
+	assert.Equal(t, "Entra ID", userTrait.GetProfile().AsMap()["identity_provider"])
+}
+
+// TestUserBuilder_List_CriticalPermissions verifies users holding critical permissions get a summary profile field.
+func TestUserBuilder_List_CriticalPermissions(t *testing.T) {
+	mockClient := &mockClient{
+		getUsersFunc: func(ctx context.Context, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error) {
+			return []client.User{
+				{
+					UserId:   "u1",
+					UserName: "admin",
+					UserSettings: map[string]interface{}{
+						"canSendEnvelope": "true",
+						"accountManagementGranular": map[string]interface{}{
+							"canManageUsers":  "true",
+							"canManageAdmins": "true",
+						},
+					},
+				},
+				{
+					UserId:       "u2",
+					UserName:     "sender",
+					UserSettings: map[string]interface{}{"canSendEnvelope": "true"},
+				},
+			}, "", nil, nil
+		},
+	}
+	builder := &userBuilder{resourceType: userResourceType, client: mockClient}
+
+	resources, _, _, err := builder.List(context.Background(), nil, &pagination.Token{})
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+
+	adminTrait, err := resource.GetUserTrait(resources[0])
+	require.NoError(t, err)
+	assert.Equal(t, "canManageUsers,canManageAdmins", adminTrait.Profile.GetFields()["critical_permissions"].GetStringValue())
+
+	senderTrait, err := resource.GetUserTrait(resources[1])
+	require.NoError(t, err)
+	assert.NotContains(t, senderTrait.Profile.GetFields(), "critical_permissions")
+}
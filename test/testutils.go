@@ -41,10 +41,20 @@ func (m *MockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 
 // MockClient is a mock client used for unit tests that simulates the real client behavior.
 type MockClient struct {
-	GetUsersFunc      func(ctx context.Context, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error)
-	GetGroupsFunc     func(ctx context.Context, opts client.PageOptions) ([]client.Group, string, annotations.Annotations, error)
-	GetGroupUsersFunc func(ctx context.Context, groupID string, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error)
-	CreateUsersFunc   func(ctx context.Context, request client.CreateUsersRequest) (*client.UserCreationResponse, annotations.Annotations, error)
+	GetUsersFunc          func(ctx context.Context, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error)
+	GetGroupsFunc         func(ctx context.Context, opts client.PageOptions) ([]client.Group, string, annotations.Annotations, error)
+	GetGroupUsersFunc     func(ctx context.Context, groupID string, opts client.PageOptions) ([]client.User, string, annotations.Annotations, error)
+	CreateUsersFunc       func(ctx context.Context, request client.CreateUsersRequest) (*client.UserCreationResponse, annotations.Annotations, error)
+	AddGroupUsersFunc     func(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error)
+	RemoveGroupUsersFunc  func(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error)
+	CreateGroupFunc       func(ctx context.Context, group client.GroupCreate) (*client.Group, annotations.Annotations, error)
+	DeleteGroupFunc       func(ctx context.Context, groupID string) (annotations.Annotations, error)
+	AddGroupBrandsFunc    func(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error)
+	RemoveGroupBrandsFunc func(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error)
+	GetGroupBrandsFunc    func(ctx context.Context, groupID string) ([]client.Brand, annotations.Annotations, error)
+	GetBrandsFunc         func(ctx context.Context) (*client.BrandsResponse, annotations.Annotations, error)
+	GetUserDetailsFunc    func(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error)
+	UpdateUserFunc        func(ctx context.Context, userID string, update client.UserUpdate) (annotations.Annotations, error)
 }
 
 // ExtendedMockClient is an extended version of MockClient with additional functionality for user details.
@@ -77,6 +87,86 @@ func (m *MockClient) GetGroupUsers(ctx context.Context, groupID string, opts cli
 	return nil, "", nil, nil
 }
 
+// AddGroupUsers adds users to a group based on the mocked function.
+func (m *MockClient) AddGroupUsers(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error) {
+	if m.AddGroupUsersFunc != nil {
+		return m.AddGroupUsersFunc(ctx, groupID, userIDs)
+	}
+	return &client.GroupUsersResponse{}, nil, nil
+}
+
+// RemoveGroupUsers removes users from a group based on the mocked function.
+func (m *MockClient) RemoveGroupUsers(ctx context.Context, groupID string, userIDs []string) (*client.GroupUsersResponse, annotations.Annotations, error) {
+	if m.RemoveGroupUsersFunc != nil {
+		return m.RemoveGroupUsersFunc(ctx, groupID, userIDs)
+	}
+	return &client.GroupUsersResponse{}, nil, nil
+}
+
+// CreateGroup creates a group based on the mocked function.
+func (m *MockClient) CreateGroup(ctx context.Context, group client.GroupCreate) (*client.Group, annotations.Annotations, error) {
+	if m.CreateGroupFunc != nil {
+		return m.CreateGroupFunc(ctx, group)
+	}
+	return &client.Group{GroupName: group.GroupName}, nil, nil
+}
+
+// DeleteGroup deletes a group based on the mocked function.
+func (m *MockClient) DeleteGroup(ctx context.Context, groupID string) (annotations.Annotations, error) {
+	if m.DeleteGroupFunc != nil {
+		return m.DeleteGroupFunc(ctx, groupID)
+	}
+	return nil, nil
+}
+
+// AddGroupBrands assigns brands to a group based on the mocked function.
+func (m *MockClient) AddGroupBrands(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error) {
+	if m.AddGroupBrandsFunc != nil {
+		return m.AddGroupBrandsFunc(ctx, groupID, brandIDs)
+	}
+	return nil, nil
+}
+
+// RemoveGroupBrands unassigns brands from a group based on the mocked function.
+func (m *MockClient) RemoveGroupBrands(ctx context.Context, groupID string, brandIDs []string) (annotations.Annotations, error) {
+	if m.RemoveGroupBrandsFunc != nil {
+		return m.RemoveGroupBrandsFunc(ctx, groupID, brandIDs)
+	}
+	return nil, nil
+}
+
+// GetGroupBrands returns the brands of a group based on the mocked function.
+func (m *MockClient) GetGroupBrands(ctx context.Context, groupID string) ([]client.Brand, annotations.Annotations, error) {
+	if m.GetGroupBrandsFunc != nil {
+		return m.GetGroupBrandsFunc(ctx, groupID)
+	}
+	return nil, nil, nil
+}
+
+// GetBrands returns the account brands based on the mocked function.
+func (m *MockClient) GetBrands(ctx context.Context) (*client.BrandsResponse, annotations.Annotations, error) {
+	if m.GetBrandsFunc != nil {
+		return m.GetBrandsFunc(ctx)
+	}
+	return &client.BrandsResponse{}, nil, nil
+}
+
+// GetUserDetails returns the details of a user based on the mocked function.
+func (m *MockClient) GetUserDetails(ctx context.Context, userID string) (*client.UserDetail, annotations.Annotations, error) {
+	if m.GetUserDetailsFunc != nil {
+		return m.GetUserDetailsFunc(ctx, userID)
+	}
+	return &client.UserDetail{UserID: userID}, nil, nil
+}
+
+// UpdateUser updates a user based on the mocked function.
+func (m *MockClient) UpdateUser(ctx context.Context, userID string, update client.UserUpdate) (annotations.Annotations, error) {
+	if m.UpdateUserFunc != nil {
+		return m.UpdateUserFunc(ctx, userID, update)
+	}
+	return nil, nil
+}
+
 // CreateUsers creates users based on the mocked function.
 func (m *MockClient) CreateUsers(ctx context.Context, request client.CreateUsersRequest) (*client.UserCreationResponse, annotations.Annotations, error) {
 	if m.CreateUsersFunc != nil {